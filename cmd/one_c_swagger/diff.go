@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"one_c_swagger/internal/config"
+	"one_c_swagger/internal/diff"
+	"one_c_swagger/internal/logger"
+	"os"
+	"slices"
+	"strings"
+)
+
+// runDiff compares a baseline specification with a revision and reports the changes. Without
+// a revision file the specification is generated from the sources, so a committed
+// openapi.json can gate changes of the configuration in CI.
+func runDiff(args []string) int {
+	flags, configPath, overrides := newFlagSet("diff")
+	format := flags.String("format", "text", "Output format: text, json or markdown")
+	failOn := flags.String("fail_on", "breaking", "Exit with code 1 on changes of this kind: breaking, any or none")
+	language := flags.String("lang", "", "Language of the generated specification (default: the first of project.languages)")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: one_c_swagger diff [flags] <base.json> [revision.json]\n\n")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() < 1 || flags.NArg() > 2 {
+		flags.Usage()
+		return 2
+	}
+
+	writers := map[string]func(io.Writer, []diff.Change) error{
+		"text":     diff.WriteText,
+		"json":     diff.WriteJSON,
+		"markdown": diff.WriteMarkdown,
+	}
+	write, ok := writers[strings.ToLower(*format)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown format %q\n", *format)
+		return 2
+	}
+	if !slices.Contains([]string{"breaking", "any", "none"}, *failOn) {
+		fmt.Fprintf(os.Stderr, "unknown -fail_on value %q\n", *failOn)
+		return 2
+	}
+
+	base, err := os.ReadFile(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	var revision []byte
+	if flags.NArg() == 2 {
+		revision, err = os.ReadFile(flags.Arg(1))
+	} else {
+		revision, err = generateRevision(*configPath, overrides, *language)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	changes, err := diff.Compare(base, revision)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	if err := write(os.Stdout, changes); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	switch {
+	case *failOn == "breaking" && diff.HasBreaking(changes):
+		return 1
+	case *failOn == "any" && len(changes) > 0:
+		return 1
+	}
+	return 0
+}
+
+// generateRevision generates the specification for the language from the sources of the
+// configuration file, serialized as it would be written to openapi.json.
+func generateRevision(configPath string, overrides *config.Overrides, language string) ([]byte, error) {
+	cfg := loadConfig(configPath, overrides)
+	languages := specLanguages(cfg)
+	if language == "" {
+		language = languages[0]
+	}
+	if !slices.Contains(languages, language) {
+		return nil, fmt.Errorf("unknown language %q", language)
+	}
+
+	slog := logger.New(cfg.Log.LogLevel, cfg.Log.LogPath)
+	openapi, err := buildSpec(cfg, language, slog)
+	if err != nil {
+		return nil, fmt.Errorf("error generating spec: %w", err)
+	}
+	spec, err := serializeSpec(openapi, "json")
+	if err != nil {
+		return nil, err
+	}
+	return []byte(spec), nil
+}
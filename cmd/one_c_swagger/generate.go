@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"one_c_swagger/internal/generator"
+	"one_c_swagger/internal/logger"
+	"one_c_swagger/internal/models"
+	"one_c_swagger/internal/validator"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// runGenerate generates the specifications and writes them to the output directory.
+// It returns the exit code: 1 if a specification could not be generated or is invalid in
+// the fail validation mode.
+func runGenerate(args []string) int {
+	flags, configPath, overrides := newFlagSet("generate")
+	versionFlag := flags.Bool("version", false, "Print version and build information")
+	flags.Parse(args)
+
+	if *versionFlag {
+		printVersion()
+		return 0
+	}
+
+	cfg := loadConfig(*configPath, overrides)
+
+	slog := logger.New(cfg.Log.LogLevel, cfg.Log.LogPath)
+
+	slog.Info("Starting one_c_swagger", "version", version, "build", build)
+	slog.Info("Config loaded", "config", cfg)
+
+	mergedServices := readServices(cfg, slog)
+	allServicesConfig, swaggerConfigs := readSwaggerConfigs(cfg, mergedServices, slog)
+	configuration := readConfigurationInfo(cfg, slog)
+
+	// Generate OpenAPI spec
+	// Create out directory if it doesn't exist
+	if _, err := os.Stat(cfg.Project.OutPath); os.IsNotExist(err) {
+		if err := os.MkdirAll(cfg.Project.OutPath, 0755); err != nil {
+			slog.Error("Error creating output directory", "path", cfg.Project.OutPath, "error", err)
+			return 1
+		}
+	}
+
+	validation := cfg.Project.Validation
+	if validation == "" {
+		validation = validator.ModeWarn
+	}
+	if validation != validator.ModeOff && validation != validator.ModeWarn && validation != validator.ModeFail {
+		slog.Error("Unknown validation mode", "validation", validation)
+		return 1
+	}
+
+	formats := cfg.Project.Output.Formats
+	if len(formats) == 0 {
+		formats = []string{"json"}
+	}
+
+	// Generate one spec per language; file names get a language suffix when there are several
+	languages := specLanguages(cfg)
+	failed := false
+	for _, language := range languages {
+		openapi, err := generator.GenerateOpenAPI(mergedServices, swaggerConfigs, allServicesConfig, configuration, generatorOptions(cfg, language), slog)
+		if err != nil {
+			slog.Error("Error generating OpenAPI object", "language", language, "error", err)
+			failed = true
+			continue
+		}
+
+		if validation != validator.ModeOff && !validateSpec(openapi, language, slog) && validation == validator.ModeFail {
+			slog.Error("Generated specification failed the structural checks, output is not written", "language", language)
+			failed = true
+			continue
+		}
+
+		baseName := "openapi"
+		if len(languages) > 1 {
+			baseName += "." + language
+		}
+		for _, format := range formats {
+			writeSpec(openapi, format, cfg.Project.OutPath, baseName, slog)
+		}
+
+		if cfg.Project.Output.Swagger2 {
+			swagger := generator.ConvertToSwagger2(openapi, slog.With("language", language))
+			swaggerName := "swagger" + strings.TrimPrefix(baseName, "openapi")
+			for _, format := range formats {
+				writeSpec(swagger, format, cfg.Project.OutPath, swaggerName, slog)
+			}
+		}
+	}
+
+	if failed {
+		return 1
+	}
+	return 0
+}
+
+// validateSpec runs the structural checks on the generated spec, logs the issues found and
+// reports whether there are no errors among them.
+func validateSpec(openapi *models.OpenAPI, language string, log *slog.Logger) bool {
+	issues, err := validator.ValidateOpenAPI(openapi)
+	if err != nil {
+		log.Error("Error running structural checks", "language", language, "error", err)
+		return false
+	}
+	for _, issue := range issues {
+		if issue.Severity == validator.SeverityError {
+			log.Error("OpenAPI structural check error", "language", language, "path", issue.Path, "message", issue.Message)
+		} else {
+			log.Warn("OpenAPI structural check warning", "language", language, "path", issue.Path, "message", issue.Message)
+		}
+	}
+	return !validator.HasErrors(issues)
+}
+
+// writeSpec serialises the spec, an OpenAPI or Swagger 2.0 document, in the given format and
+// saves it to the output directory.
+func writeSpec(document interface{}, format, outPath, baseName string, log *slog.Logger) {
+	spec, err := serializeSpec(document, format)
+	if err != nil {
+		log.Error("Error generating spec", "format", format, "error", err)
+		return
+	}
+
+	fileName := baseName + "." + strings.ToLower(format)
+	specFile := filepath.Join(outPath, fileName)
+	if err := os.WriteFile(specFile, []byte(spec), 0644); err != nil {
+		log.Error("Error writing spec file", "path", specFile, "error", err)
+	} else {
+		log.Info("Successfully generated "+fileName, "path", specFile)
+	}
+}
+
+// serializeSpec renders the spec in the given format: json or yaml.
+func serializeSpec(document interface{}, format string) (string, error) {
+	switch strings.ToLower(format) {
+	case "json":
+		return generator.ToJSON(document)
+	case "yaml":
+		return generator.ToYAML(document)
+	default:
+		return "", fmt.Errorf("unknown output format %q", format)
+	}
+}
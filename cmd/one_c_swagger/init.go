@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"one_c_swagger/internal/config"
+	"os"
+	"path/filepath"
+)
+
+// allServicesTemplate is the skeleton of the all services config created by init.
+const allServicesTemplate = `{
+    "servers": [
+        {
+            "url": "http://localhost/base/hs",
+            "description": "Основной сервер"
+        }
+    ],
+    "defaultResponses": {},
+    "components": {
+        "securitySchemes": {
+            "basicAuth": {
+                "type": "http",
+                "scheme": "basic"
+            }
+        },
+        "schemas": {},
+        "responses": {},
+        "headers": {}
+    }
+}
+`
+
+// runInit writes a configuration file with default settings, adjusted by the command-line
+// overrides, and creates the swagger config directory with an all services config skeleton.
+func runInit(args []string) int {
+	flags, configPath, overrides := newFlagSet("init")
+	force := flags.Bool("force", false, "Overwrite an existing configuration file")
+	flags.Parse(args)
+
+	if _, err := os.Stat(*configPath); err == nil && !*force {
+		fmt.Fprintf(os.Stderr, "%s already exists, use -force to overwrite it\n", *configPath)
+		return 1
+	}
+
+	cfg := config.DefaultConfig()
+	if err := overrides.Apply(&cfg.Project); err != nil {
+		fmt.Fprintf(os.Stderr, "error applying command-line settings: %v\n", err)
+		return 1
+	}
+
+	if dir := filepath.Dir(*configPath); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			fmt.Fprintf(os.Stderr, "error creating %s: %v\n", dir, err)
+			return 1
+		}
+	}
+	if err := config.SaveConfig(*configPath, cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "error writing %s: %v\n", *configPath, err)
+		return 1
+	}
+	fmt.Printf("Created %s\n", *configPath)
+
+	if cfg.Project.SwaggerConfigPath == "" || cfg.Project.AllServicesConfigFileName == "" {
+		return 0
+	}
+	allServicesPath := filepath.Join(cfg.Project.SwaggerConfigPath, cfg.Project.AllServicesConfigFileName)
+	if _, err := os.Stat(allServicesPath); err == nil {
+		return 0
+	}
+	if err := os.MkdirAll(cfg.Project.SwaggerConfigPath, 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "error creating %s: %v\n", cfg.Project.SwaggerConfigPath, err)
+		return 1
+	}
+	if err := os.WriteFile(allServicesPath, []byte(allServicesTemplate), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "error writing %s: %v\n", allServicesPath, err)
+		return 1
+	}
+	fmt.Printf("Created %s\n", allServicesPath)
+	return 0
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"one_c_swagger/internal/logger"
+	"one_c_swagger/internal/reader"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// baseSource labels objects of the base configuration in the inventory.
+const baseSource = "configuration"
+
+// inventoryRow is a method of an HTTP service with everything needed to locate it.
+type inventoryRow struct {
+	Service    string `json:"service"`
+	RootURL    string `json:"root_url"`
+	Template   string `json:"template"`
+	Method     string `json:"method"`
+	HTTPMethod string `json:"http_method"`
+	Handler    string `json:"handler"`
+	Source     string `json:"source"`
+}
+
+var inventoryHeader = []string{"SERVICE", "ROOT URL", "TEMPLATE", "METHOD", "HTTP METHOD", "HANDLER", "SOURCE"}
+
+func (r inventoryRow) fields() []string {
+	return []string{r.Service, r.RootURL, r.Template, r.Method, r.HTTPMethod, r.Handler, r.Source}
+}
+
+// runList prints the merged HTTP services, one row per method, as a table, JSON or CSV.
+func runList(args []string) int {
+	flags, configPath, overrides := newFlagSet("list")
+	format := flags.String("format", "table", "Output format: table, json or csv")
+	flags.Parse(args)
+	cfg := loadConfig(*configPath, overrides)
+
+	slog := logger.New(cfg.Log.LogLevel, cfg.Log.LogPath)
+	rows := inventory(readServices(cfg, slog))
+
+	var err error
+	switch strings.ToLower(*format) {
+	case "table":
+		err = writeInventoryTable(os.Stdout, rows)
+	case "json":
+		err = writeInventoryJSON(os.Stdout, rows)
+	case "csv":
+		err = writeInventoryCSV(os.Stdout, rows)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown format %q\n", *format)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+// inventory flattens the services into rows. Templates without methods get a row of their own.
+func inventory(services []reader.HTTPService) []inventoryRow {
+	var rows []inventoryRow
+	for _, service := range services {
+		for _, urlTemplate := range service.URLTemplates {
+			row := inventoryRow{
+				Service:  service.Properties.Name,
+				RootURL:  service.Properties.RootURL,
+				Template: urlTemplate.Properties.Template,
+				Source:   sourceLabel(urlTemplate.Source),
+			}
+			if len(urlTemplate.Methods) == 0 {
+				rows = append(rows, row)
+			}
+			for _, method := range urlTemplate.Methods {
+				row.Method = method.Properties.Name
+				row.HTTPMethod = method.Properties.HTTPMethod
+				row.Handler = method.Properties.Handler
+				row.Source = sourceLabel(method.Source)
+				rows = append(rows, row)
+			}
+		}
+		if len(service.URLTemplates) == 0 {
+			rows = append(rows, inventoryRow{
+				Service: service.Properties.Name,
+				RootURL: service.Properties.RootURL,
+				Source:  sourceLabel(service.Source),
+			})
+		}
+	}
+	return rows
+}
+
+func sourceLabel(source string) string {
+	if source == "" {
+		return baseSource
+	}
+	return source
+}
+
+func writeInventoryTable(w io.Writer, rows []inventoryRow) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(inventoryHeader, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row.fields(), "\t"))
+	}
+	return tw.Flush()
+}
+
+func writeInventoryJSON(w io.Writer, rows []inventoryRow) error {
+	if rows == nil {
+		rows = []inventoryRow{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(rows)
+}
+
+func writeInventoryCSV(w io.Writer, rows []inventoryRow) error {
+	writer := csv.NewWriter(w)
+	header := make([]string, len(inventoryHeader))
+	for i, name := range inventoryHeader {
+		header[i] = strings.ToLower(strings.ReplaceAll(name, " ", "_"))
+	}
+	writer.Write(header)
+	for _, row := range rows {
+		writer.Write(row.fields())
+	}
+	writer.Flush()
+	return writer.Error()
+}
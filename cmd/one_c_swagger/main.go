@@ -6,11 +6,8 @@ import (
 	"log"
 	"one_c_swagger/internal/config"
 	"one_c_swagger/internal/generator"
-	"one_c_swagger/internal/logger"
-	"one_c_swagger/internal/merger"
-	"one_c_swagger/internal/reader"
 	"os"
-	"path/filepath"
+	"strings"
 )
 
 var (
@@ -18,126 +15,100 @@ var (
 	build   = "local"
 )
 
-func main() {
-	versionFlag := flag.Bool("version", false, "Print version and build information")
-	configPath := flag.String("config", "configs/config.json", "Path to the configuration file")
-	flag.Parse()
-
-	if *versionFlag {
-		fmt.Printf("Version: %s\nBuild: %s\n", version, build)
-		return
-	}
-
-	cfg, err := config.LoadConfig(*configPath)
-	if err != nil {
-		log.Fatalf("error loading config: %v", err)
-	}
+// command is a subcommand of the application. run receives the arguments after the command
+// name and returns the exit code.
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) int
+}
+
+var commands = []command{
+	{"generate", "Generate the OpenAPI specification (default)", runGenerate},
+	{"validate", "Check swagger config overlays against the HTTP services", runValidate},
+	{"init", "Create a configuration file and a swagger config skeleton", runInit},
+	{"serve", "Serve the specification and Swagger UI over HTTP", runServe},
+	{"list", "List HTTP services, templates and methods", runList},
+	{"diff", "Compare a specification with a baseline and report breaking changes", runDiff},
+}
 
-	slog := logger.New(cfg.Log.LogLevel, cfg.Log.LogPath)
-
-	slog.Info("Starting one_c_swagger", "version", version, "build", build)
-	slog.Info("Config loaded", "config", cfg)
-
-	var baseServices []reader.HTTPService
-	var extServices []reader.HTTPService
-
-	// Read base configuration
-	if cfg.Project.ConfigurationPath != "" {
-		if _, err := os.Stat(cfg.Project.ConfigurationPath); !os.IsNotExist(err) {
-			httpServicesPath := filepath.Join(cfg.Project.ConfigurationPath, "HTTPServices")
-			if _, err := os.Stat(httpServicesPath); !os.IsNotExist(err) {
-				services, err := reader.ReadHTTPServices(httpServicesPath, slog)
-				if err != nil {
-					slog.Error("Error reading http services from configuration", "path", httpServicesPath, "error", err)
-				} else {
-					slog.Info("Found services in configuration", "count", len(services))
-					baseServices = append(baseServices, services...)
-				}
-			}
-		} else {
-			slog.Warn("Configuration path does not exist", "path", cfg.Project.ConfigurationPath)
-		}
+func main() {
+	args := os.Args[1:]
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		// Without a command the application generates the specification, as it always did
+		os.Exit(runGenerate(args))
 	}
 
-	// Read extensions
-	if cfg.Project.ExtensionsPath != "" {
-		if _, err := os.Stat(cfg.Project.ExtensionsPath); !os.IsNotExist(err) {
-			extensions, err := os.ReadDir(cfg.Project.ExtensionsPath)
-			if err != nil {
-				slog.Error("Error reading extensions directory", "path", cfg.Project.ExtensionsPath, "error", err)
-			} else {
-				for _, ext := range extensions {
-					if ext.IsDir() {
-						extHttpServicesPath := filepath.Join(cfg.Project.ExtensionsPath, ext.Name(), "HTTPServices")
-						if _, err := os.Stat(extHttpServicesPath); !os.IsNotExist(err) {
-							services, err := reader.ReadHTTPServices(extHttpServicesPath, slog)
-							if err != nil {
-								slog.Error("Error reading http services from extension", "path", extHttpServicesPath, "error", err)
-							} else {
-								slog.Info("Found services in extension", "extension", ext.Name(), "count", len(services))
-								extServices = append(extServices, services...)
-							}
-						}
-					}
-				}
-			}
-		} else {
-			slog.Warn("Extensions path does not exist", "path", cfg.Project.ExtensionsPath)
-		}
+	switch args[0] {
+	case "version":
+		printVersion()
+		return
+	case "help":
+		usage()
+		return
 	}
-
-	mergedServices := merger.MergeServices(baseServices, extServices)
-	slog.Info("Total http services after merge", "count", len(mergedServices))
-
-	// Read all services config
-	var allServicesConfig *reader.AllServicesConfig
-	if cfg.Project.SwaggerConfigPath != "" && cfg.Project.AllServicesConfigFileName != "" {
-		allServicesConfig, err = reader.ReadAllServicesConfigFile(cfg.Project.SwaggerConfigPath, cfg.Project.AllServicesConfigFileName, slog)
-		if err != nil {
-			slog.Error("Error reading all services config file", "error", err)
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			os.Exit(cmd.run(args[1:]))
 		}
 	}
-
-	// Read swagger configs
-	swaggerConfigs := make(map[string]*reader.SwaggerConfig)
-	if cfg.Project.SwaggerConfigPath != "" {
-		for _, service := range mergedServices {
-			swaggerConfig, err := reader.ReadSwaggerConfigFile(cfg.Project.SwaggerConfigPath, service.Properties.Name, slog)
-			if err != nil {
-				slog.Error("Error reading swagger-config.json", "service", service.Properties.Name, "error", err)
-			}
-			if swaggerConfig != nil {
-				swaggerConfigs[service.Properties.Name] = swaggerConfig
-			}
-		}
+	fmt.Fprintf(os.Stderr, "unknown command %q\n\n", args[0])
+	usage()
+	os.Exit(2)
+}
+
+func printVersion() {
+	fmt.Printf("Version: %s\nBuild: %s\n", version, build)
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: one_c_swagger [command] [flags]\n\nCommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", cmd.name, cmd.summary)
 	}
-
-	// Generate OpenAPI spec
-	openapi, err := generator.GenerateOpenAPI(mergedServices, swaggerConfigs, allServicesConfig, slog)
+	fmt.Fprintf(os.Stderr, "  %-10s %s\n", "version", "Print version and build information")
+	fmt.Fprintf(os.Stderr, "\nRun 'one_c_swagger <command> -h' for the flags of a command.\n")
+}
+
+// newFlagSet creates the flag set of a command with the flags shared by all commands: the
+// path of the configuration file and an override for every project setting.
+func newFlagSet(name string) (*flag.FlagSet, *string, *config.Overrides) {
+	flags := flag.NewFlagSet(name, flag.ExitOnError)
+	configPath := flags.String("config", "configs/config.json", "Path to the configuration file")
+	overrides := config.RegisterOverrides(flags)
+	return flags, configPath, overrides
+}
+
+// loadConfig reads the configuration file and applies the command-line overrides.
+func loadConfig(path string, overrides *config.Overrides) *config.Config {
+	cfg, err := config.LoadConfig(path)
 	if err != nil {
-		slog.Error("Error generating OpenAPI object", "error", err)
-		return
+		log.Fatalf("error loading config: %v", err)
 	}
-
-	// Create out directory if it doesn't exist
-	if _, err := os.Stat(cfg.Project.OutPath); os.IsNotExist(err) {
-		if err := os.MkdirAll(cfg.Project.OutPath, 0755); err != nil {
-			slog.Error("Error creating output directory", "path", cfg.Project.OutPath, "error", err)
-			return
-		}
+	if err := overrides.Apply(&cfg.Project); err != nil {
+		log.Fatalf("error applying command-line settings: %v", err)
 	}
-
-	// Generate and save JSON spec
-	jsonSpec, err := generator.ToJSON(openapi)
-	if err != nil {
-		slog.Error("Error generating JSON spec", "error", err)
-	} else {
-		jsonFile := filepath.Join(cfg.Project.OutPath, "openapi.json")
-		if err := os.WriteFile(jsonFile, []byte(jsonSpec), 0644); err != nil {
-			slog.Error("Error writing json spec file", "path", jsonFile, "error", err)
-		} else {
-			slog.Info("Successfully generated openapi.json", "path", jsonFile)
-		}
+	return cfg
+}
+
+// specLanguages returns the languages to generate specifications for; "" stands for the
+// single specification of a configuration without languages.
+func specLanguages(cfg *config.Config) []string {
+	if len(cfg.Project.Languages) == 0 {
+		return []string{""}
 	}
-
-}
\ No newline at end of file
+	return cfg.Project.Languages
+}
+
+func generatorOptions(cfg *config.Config, language string) generator.Options {
+	return generator.Options{
+		WildcardStrategy:    cfg.Project.WildcardStrategy,
+		WildcardParamName:   cfg.Project.WildcardParamName,
+		OperationIDStrategy: cfg.Project.OperationIDStrategy,
+		Languages:           cfg.Project.LanguageChain(language),
+		Transliteration:     cfg.Project.Transliteration,
+		AnalyzeBSL:          !cfg.Project.SkipBSLAnalysis,
+		Strict:              cfg.Project.Strict,
+		OpenAPIVersion:      cfg.Project.Output.OpenAPIVersion,
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"one_c_swagger/internal/config"
+	"one_c_swagger/internal/generator"
+	"one_c_swagger/internal/logger"
+	"one_c_swagger/internal/models"
+	"os"
+	"slices"
+)
+
+// swaggerUIPage loads Swagger UI from a CDN and points it at the served specifications.
+var swaggerUIPage = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <title>1C HTTP Services</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-standalone-preset.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({
+      urls: {{.}},
+      dom_id: "#swagger-ui",
+      presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+      layout: "StandaloneLayout"
+    });
+  </script>
+</body>
+</html>
+`))
+
+// specURL is an entry of the Swagger UI spec selector.
+type specURL struct {
+	URL  string `json:"url"`
+	Name string `json:"name"`
+}
+
+// runServe serves Swagger UI and the specifications over HTTP. The specifications are
+// generated on every request, so changes of the sources are visible after a page reload.
+func runServe(args []string) int {
+	flags, configPath, overrides := newFlagSet("serve")
+	addr := flags.String("addr", "localhost:8080", "Address to listen on")
+	flags.Parse(args)
+	cfg := loadConfig(*configPath, overrides)
+
+	slog := logger.New(cfg.Log.LogLevel, cfg.Log.LogPath)
+	slog.Info("Starting one_c_swagger server", "version", version, "build", build, "addr", *addr)
+
+	languages := specLanguages(cfg)
+	var urls []specURL
+	for _, language := range languages {
+		url := specURL{URL: "openapi.json", Name: "openapi.json"}
+		if language != "" {
+			url = specURL{URL: "openapi.json?lang=" + language, Name: language}
+		}
+		urls = append(urls, url)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := swaggerUIPage.Execute(w, urls); err != nil {
+			slog.Error("Error rendering Swagger UI page", "error", err)
+		}
+	})
+	for format, contentType := range map[string]string{"json": "application/json", "yaml": "application/yaml"} {
+		mux.HandleFunc("/openapi."+format, func(w http.ResponseWriter, r *http.Request) {
+			language := r.URL.Query().Get("lang")
+			if language == "" {
+				language = languages[0]
+			}
+			if !slices.Contains(languages, language) {
+				http.Error(w, fmt.Sprintf("unknown language %q", language), http.StatusNotFound)
+				return
+			}
+
+			openapi, err := buildSpec(cfg, language, slog)
+			if err == nil {
+				var spec string
+				spec, err = serializeSpec(openapi, format)
+				if err == nil {
+					w.Header().Set("Content-Type", contentType+"; charset=utf-8")
+					w.Write([]byte(spec))
+					return
+				}
+			}
+			slog.Error("Error generating spec", "language", language, "format", format, "error", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		})
+	}
+
+	fmt.Printf("Serving Swagger UI on http://%s/\n", *addr)
+	if err := http.ListenAndServe(*addr, mux); err != nil {
+		slog.Error("Server stopped", "error", err)
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+// buildSpec reads the sources and generates the specification for the language.
+func buildSpec(cfg *config.Config, language string, log *slog.Logger) (*models.OpenAPI, error) {
+	services := readServices(cfg, log)
+	allServicesConfig, swaggerConfigs := readSwaggerConfigs(cfg, services, log)
+	configuration := readConfigurationInfo(cfg, log)
+	return generator.GenerateOpenAPI(services, swaggerConfigs, allServicesConfig, configuration, generatorOptions(cfg, language), log)
+}
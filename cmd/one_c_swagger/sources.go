@@ -0,0 +1,130 @@
+package main
+
+import (
+	"log/slog"
+	"one_c_swagger/internal/config"
+	"one_c_swagger/internal/merger"
+	"one_c_swagger/internal/reader"
+	"os"
+	"path/filepath"
+)
+
+// readServices reads the HTTP services of the base configuration and of the extensions and
+// merges them.
+func readServices(cfg *config.Config, log *slog.Logger) []reader.HTTPService {
+	var baseServices []reader.HTTPService
+	var extServices []reader.HTTPService
+
+	// Read base configuration
+	if cfg.Project.ConfigurationPath != "" {
+		if _, err := os.Stat(cfg.Project.ConfigurationPath); !os.IsNotExist(err) {
+			services, err := reader.ReadProjectHTTPServices(cfg.Project.ConfigurationPath, log)
+			if err != nil {
+				log.Error("Error reading http services from configuration", "path", cfg.Project.ConfigurationPath, "error", err)
+			} else {
+				log.Info("Found services in configuration", "count", len(services))
+				baseServices = append(baseServices, services...)
+			}
+		} else {
+			log.Warn("Configuration path does not exist", "path", cfg.Project.ConfigurationPath)
+		}
+	}
+
+	// Read extensions in directory name order, so that the merge result is stable
+	if cfg.Project.ExtensionsPath != "" {
+		if _, err := os.Stat(cfg.Project.ExtensionsPath); !os.IsNotExist(err) {
+			extensions, err := os.ReadDir(cfg.Project.ExtensionsPath)
+			if err != nil {
+				log.Error("Error reading extensions directory", "path", cfg.Project.ExtensionsPath, "error", err)
+			} else {
+				for _, ext := range extensions {
+					if ext.IsDir() {
+						extPath := filepath.Join(cfg.Project.ExtensionsPath, ext.Name())
+						services, err := reader.ReadProjectHTTPServices(extPath, log)
+						if err != nil {
+							log.Error("Error reading http services from extension", "path", extPath, "error", err)
+						} else if len(services) > 0 {
+							log.Info("Found services in extension", "extension", ext.Name(), "count", len(services))
+							for i := range services {
+								services[i].SetSource(ext.Name())
+							}
+							extServices = append(extServices, services...)
+						}
+					}
+				}
+			}
+		} else {
+			log.Warn("Extensions path does not exist", "path", cfg.Project.ExtensionsPath)
+		}
+	}
+
+	mergedServices := merger.MergeServices(baseServices, extServices)
+	log.Info("Total http services after merge", "count", len(mergedServices))
+	return mergedServices
+}
+
+// readConfigurationInfo reads the properties of the base configuration and of every extension
+// in the extensions directory. An extension without a configuration file is listed by its
+// directory name.
+func readConfigurationInfo(cfg *config.Config, log *slog.Logger) *reader.ConfigurationInfo {
+	info := &reader.ConfigurationInfo{}
+
+	if cfg.Project.ConfigurationPath != "" {
+		configuration, err := reader.ReadProjectConfiguration(cfg.Project.ConfigurationPath, log)
+		if err != nil {
+			log.Error("Error reading configuration properties", "path", cfg.Project.ConfigurationPath, "error", err)
+		}
+		info.Configuration = configuration
+	}
+
+	if cfg.Project.ExtensionsPath != "" {
+		extensions, err := os.ReadDir(cfg.Project.ExtensionsPath)
+		if err != nil && !os.IsNotExist(err) {
+			log.Error("Error reading extensions directory", "path", cfg.Project.ExtensionsPath, "error", err)
+		}
+		for _, ext := range extensions {
+			if !ext.IsDir() {
+				continue
+			}
+			extPath := filepath.Join(cfg.Project.ExtensionsPath, ext.Name())
+			extension, err := reader.ReadProjectConfiguration(extPath, log)
+			if err != nil {
+				log.Error("Error reading extension properties", "path", extPath, "error", err)
+			}
+			if extension == nil {
+				extension = &reader.Configuration{}
+			}
+			extension.Source = ext.Name()
+			info.Extensions = append(info.Extensions, *extension)
+		}
+	}
+	return info
+}
+
+// readSwaggerConfigs reads the all services config and the overlays of the services.
+func readSwaggerConfigs(cfg *config.Config, mergedServices []reader.HTTPService, log *slog.Logger) (*reader.AllServicesConfig, map[string]*reader.SwaggerConfig) {
+	// Read all services config
+	var allServicesConfig *reader.AllServicesConfig
+	if cfg.Project.SwaggerConfigPath != "" && cfg.Project.AllServicesConfigFileName != "" {
+		var err error
+		allServicesConfig, err = reader.ReadAllServicesConfigFile(cfg.Project.SwaggerConfigPath, cfg.Project.AllServicesConfigFileName, log)
+		if err != nil {
+			log.Error("Error reading all services config file", "error", err)
+		}
+	}
+
+	// Read swagger configs
+	swaggerConfigs := make(map[string]*reader.SwaggerConfig)
+	if cfg.Project.SwaggerConfigPath != "" {
+		for _, service := range mergedServices {
+			swaggerConfig, err := reader.ReadSwaggerConfigFile(cfg.Project.SwaggerConfigPath, service.Properties.Name, log)
+			if err != nil {
+				log.Error("Error reading swagger-config.json", "service", service.Properties.Name, "error", err)
+			}
+			if swaggerConfig != nil {
+				swaggerConfigs[service.Properties.Name] = swaggerConfig
+			}
+		}
+	}
+	return allServicesConfig, swaggerConfigs
+}
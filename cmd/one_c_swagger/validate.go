@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"one_c_swagger/internal/logger"
+	"one_c_swagger/internal/reader"
+	"one_c_swagger/internal/validator"
+	"path/filepath"
+)
+
+// runValidate cross-checks the overlay files against the HTTP services, prints the issues
+// found and returns the exit code: 1 if there are errors, 0 otherwise.
+func runValidate(args []string) int {
+	flags, configPath, overrides := newFlagSet("validate")
+	flags.Parse(args)
+	cfg := loadConfig(*configPath, overrides)
+
+	slog := logger.New(cfg.Log.LogLevel, cfg.Log.LogPath)
+	slog.Info("Validating swagger configs", "version", version, "build", build)
+
+	if cfg.Project.SwaggerConfigPath == "" {
+		fmt.Println("swagger_config_path is not set, nothing to validate")
+		return 0
+	}
+
+	services := readServices(cfg, slog)
+	var issues []validator.Issue
+	checked := 0
+
+	var allServicesConfig *reader.AllServicesConfig
+	if cfg.Project.AllServicesConfigFileName != "" {
+		var err error
+		allServicesConfig, err = reader.ReadAllServicesConfigFile(cfg.Project.SwaggerConfigPath, cfg.Project.AllServicesConfigFileName, slog)
+		if err != nil {
+			issues = append(issues, readIssue(cfg.Project.AllServicesConfigFileName, err))
+		}
+		if err != nil || allServicesConfig != nil {
+			checked++
+		}
+	}
+
+	names, err := reader.ListSwaggerConfigFiles(cfg.Project.SwaggerConfigPath, cfg.Project.AllServicesConfigFileName)
+	if err != nil {
+		fmt.Printf("error: %s: %v\n", filepath.Clean(cfg.Project.SwaggerConfigPath), err)
+		return 1
+	}
+	checked += len(names)
+	overlays := make(map[string]*reader.SwaggerConfig)
+	for _, name := range names {
+		overlay, err := reader.ReadSwaggerConfigFile(cfg.Project.SwaggerConfigPath, name, slog)
+		if err != nil {
+			issues = append(issues, readIssue(name+".json", err))
+			continue
+		}
+		overlays[name] = overlay
+	}
+
+	issues = append(issues, validator.ValidateOverlays(services, overlays, allServicesConfig, cfg.Project.AllServicesConfigFileName)...)
+
+	errors, warnings := 0, 0
+	for _, issue := range issues {
+		fmt.Println(issue)
+		if issue.Severity == validator.SeverityError {
+			errors++
+		} else {
+			warnings++
+		}
+		slog.Info("Swagger config issue", "severity", issue.Severity, "file", issue.File, "path", issue.Path, "message", issue.Message)
+	}
+	fmt.Printf("%d files checked: %d errors, %d warnings\n", checked, errors, warnings)
+
+	if errors > 0 {
+		return 1
+	}
+	return 0
+}
+
+func readIssue(file string, err error) validator.Issue {
+	return validator.Issue{Severity: validator.SeverityError, File: file, Path: "/", Message: err.Error()}
+}
@@ -0,0 +1,348 @@
+package bsl
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Analysis describes what an HTTP service handler reads from the request and which responses
+// it produces. The handler and the local module methods it calls are scanned.
+type Analysis struct {
+	// QueryParams lists names read via Запрос.ПараметрыЗапроса.
+	QueryParams []string
+	// URLParams lists names read via Запрос.ПараметрыURL.
+	URLParams []string
+	// RequestHeaders lists names read via Запрос.Заголовки.
+	RequestHeaders []string
+	// Responses lists the responses created with literal status codes, ordered by code.
+	Responses []Response
+	// Body is the kind of request body the handler reads, or an empty string.
+	Body BodyKind
+}
+
+// BodyKind classifies the request body read by a handler.
+type BodyKind string
+
+const (
+	// BodyText is read with ПолучитьТелоКакСтроку.
+	BodyText BodyKind = "text"
+	// BodyBinary is read with ПолучитьТелоКакПоток or ПолучитьТелоКакДвоичныеДанные.
+	BodyBinary BodyKind = "binary"
+	// BodyJSON is read as a string and parsed as JSON.
+	BodyJSON BodyKind = "json"
+)
+
+// Response is a response created with Новый HTTPСервисОтвет(<code>).
+type Response struct {
+	Code int
+	// Headers lists the headers set with Ответ.Заголовки.Вставить("<name>", ...) on the
+	// response variable after it was created, except Content-Type.
+	Headers []string
+	// ContentTypes lists literal values of the Content-Type header without parameters.
+	ContentTypes []string
+}
+
+var (
+	queryOptionsNames = []string{"ПараметрыЗапроса", "QueryOptions"}
+	urlParamsNames    = []string{"ПараметрыURL", "URLParameters"}
+	headersNames      = []string{"Заголовки", "Headers"}
+	getNames          = []string{"Получить", "Get"}
+	insertNames       = []string{"Вставить", "Insert"}
+	bodyTextNames     = []string{"ПолучитьТелоКакСтроку", "GetBodyAsString"}
+	bodyBinaryNames   = []string{"ПолучитьТелоКакПоток", "ПолучитьТелоКакДвоичныеДанные", "GetBodyAsStream", "GetBodyAsBinaryData"}
+	readJSONNames     = []string{"ПрочитатьJSON", "ReadJSON"}
+	statementPrefixes = []string{";", "Тогда", "Иначе", "Цикл", "Попытка", "Исключение", "Экспорт", "Then", "Else", "Do", "Try", "Except", "Export"}
+	newNames          = []string{"Новый", "New"}
+	responseTypeNames = []string{"HTTPСервисОтвет", "HTTPServiceResponse"}
+)
+
+// Analyze scans the handler method of the module. The first parameter of the handler is
+// taken as the request object; calls of local methods are followed, and a parameter that
+// receives the request becomes the request object of the callee. It returns nil when the
+// module has no such method.
+func (m *Module) Analyze(handler string) *Analysis {
+	method := m.Method(handler)
+	if method == nil {
+		return nil
+	}
+
+	s := &scanner{
+		module:    m,
+		analysis:  &Analysis{},
+		responses: make(map[int]*Response),
+		visited:   make(map[string]bool),
+	}
+	var request []string
+	if len(method.Params) > 0 {
+		request = []string{method.Params[0]}
+	}
+	s.scan(method, request)
+
+	codes := make([]int, 0, len(s.responses))
+	for code := range s.responses {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+
+	switch {
+	case s.bodyText && s.readsJSON:
+		s.analysis.Body = BodyJSON
+	case s.bodyBinary:
+		s.analysis.Body = BodyBinary
+	case s.bodyText:
+		s.analysis.Body = BodyText
+	}
+	for _, code := range codes {
+		s.analysis.Responses = append(s.analysis.Responses, *s.responses[code])
+	}
+
+	return s.analysis
+}
+
+type scanner struct {
+	module    *Module
+	analysis  *Analysis
+	responses map[int]*Response
+	visited   map[string]bool
+
+	bodyText   bool
+	bodyBinary bool
+	readsJSON  bool
+}
+
+// scan collects usages in the method body; request holds the names of the variables that
+// refer to the request object.
+func (s *scanner) scan(method *Method, request []string) {
+	// A method is scanned once per set of request variables.
+	key := method.Name + "(" + strings.Join(request, ",") + ")"
+	if s.visited[key] {
+		return
+	}
+	s.visited[key] = true
+
+	// responseVars maps the variables holding a response to its status code.
+	responseVars := make(map[string]int)
+
+	body := method.Body
+	for i := range body {
+		switch {
+		case isKeyword(body[i], request):
+			name, next, ok := member(body, i+1)
+			if !ok {
+				continue
+			}
+			accessed := Token{Kind: Ident, Text: name}
+			switch {
+			case isKeyword(accessed, bodyTextNames):
+				s.bodyText = true
+				// The body passed straight into a JSON helper, e.g. ДанныеJSON(Запрос.ПолучитьТелоКакСтроку())
+				if i >= 2 && at(body, i-1, "(") && body[i-2].Kind == Ident && strings.Contains(strings.ToLower(body[i-2].Text), "json") {
+					s.readsJSON = true
+				}
+			case isKeyword(accessed, bodyBinaryNames):
+				s.bodyBinary = true
+			default:
+				s.scanRequestAccess(body, name, next)
+			}
+		case isKeyword(body[i], readJSONNames) && at(body, i+1, "("):
+			s.readsJSON = true
+		case isKeyword(body[i], newNames) && at(body, i+1, responseTypeNames...) && at(body, i+2, "("):
+			code, ok := statusCode(body, i+3)
+			if !ok {
+				continue
+			}
+			s.response(code)
+			if i >= 2 && at(body, i-1, "=") && body[i-2].Kind == Ident {
+				responseVars[strings.ToLower(body[i-2].Text)] = code
+			}
+		case body[i].Kind == Ident && at(body, i+1, "(") && (i == 0 || !at(body, i-1, ".")):
+			callee := s.module.Method(body[i].Text)
+			if callee == nil {
+				continue
+			}
+			var calleeRequest []string
+			for pos, arg := range callArguments(body, i+1) {
+				if len(arg) == 1 && isKeyword(arg[0], request) && pos < len(callee.Params) {
+					calleeRequest = append(calleeRequest, callee.Params[pos])
+				}
+			}
+			s.scan(callee, calleeRequest)
+		case body[i].Kind == Ident && (i == 0 || !at(body, i-1, ".")):
+			code, ok := responseVars[strings.ToLower(body[i].Text)]
+			if !ok {
+				continue
+			}
+			if statementStart(body, i) && at(body, i+1, "=") && !(at(body, i+2, newNames...) && at(body, i+3, responseTypeNames...)) {
+				// The variable is assigned a response created elsewhere.
+				delete(responseVars, strings.ToLower(body[i].Text))
+				continue
+			}
+			if name, next, ok := member(body, i+1); ok && isKeyword(Token{Kind: Ident, Text: name}, headersNames) {
+				if header, value, ok := headerAssignment(body, next); ok {
+					s.responseHeader(code, header, value)
+				}
+			}
+		}
+	}
+}
+
+func (s *scanner) response(code int) *Response {
+	response, ok := s.responses[code]
+	if !ok {
+		response = &Response{Code: code}
+		s.responses[code] = response
+	}
+	return response
+}
+
+// responseHeader records a header set on the response with the given code. A literal
+// Content-Type value is recorded as a media type instead.
+func (s *scanner) responseHeader(code int, header, value string) {
+	response := s.response(code)
+	if strings.EqualFold(header, "Content-Type") {
+		if mediaType := strings.TrimSpace(strings.Split(value, ";")[0]); mediaType != "" {
+			response.ContentTypes = appendUnique(response.ContentTypes, mediaType)
+		}
+		return
+	}
+	response.Headers = appendUnique(response.Headers, header)
+}
+
+// scanRequestAccess recognises the access to the named request member; position i follows
+// the member name.
+func (s *scanner) scanRequestAccess(body []Token, name string, i int) {
+	accessed := Token{Kind: Ident, Text: name}
+	key, ok := collectionKey(body, i)
+	if !ok {
+		return
+	}
+	switch {
+	case isKeyword(accessed, queryOptionsNames):
+		s.analysis.QueryParams = appendUnique(s.analysis.QueryParams, key)
+	case isKeyword(accessed, urlParamsNames):
+		s.analysis.URLParams = appendUnique(s.analysis.URLParams, key)
+	case isKeyword(accessed, headersNames):
+		s.analysis.RequestHeaders = appendUnique(s.analysis.RequestHeaders, key)
+	}
+}
+
+// member reads a member access at position i, either .Имя or ["Имя"]. It returns the member
+// name and the position after the access.
+func member(tokens []Token, i int) (string, int, bool) {
+	if at(tokens, i, ".") && i+1 < len(tokens) && tokens[i+1].Kind == Ident {
+		return tokens[i+1].Text, i + 2, true
+	}
+	if at(tokens, i, "[") && i+2 < len(tokens) && tokens[i+1].Kind == String && at(tokens, i+2, "]") {
+		return tokens[i+1].Text, i + 3, true
+	}
+	return "", i, false
+}
+
+// headerAssignment reads a header set on a header collection at position i: either
+// .Вставить("name", value) or ["name"] = value. The value is returned only when it is a
+// string literal.
+func headerAssignment(tokens []Token, i int) (string, string, bool) {
+	var name string
+	var value []Token
+	switch {
+	case at(tokens, i, ".") && at(tokens, i+1, insertNames...) && at(tokens, i+2, "("):
+		args := callArguments(tokens, i+2)
+		if len(args[0]) != 1 || args[0][0].Kind != String {
+			return "", "", false
+		}
+		name = args[0][0].Text
+		if len(args) > 1 {
+			value = args[1]
+		}
+	case at(tokens, i, "[") && i+2 < len(tokens) && tokens[i+1].Kind == String && at(tokens, i+2, "]") && at(tokens, i+3, "="):
+		name = tokens[i+1].Text
+		for j := i + 4; j < len(tokens) && !at(tokens, j, ";"); j++ {
+			value = append(value, tokens[j])
+		}
+	default:
+		return "", "", false
+	}
+
+	if len(value) == 1 && value[0].Kind == String {
+		return name, value[0].Text, true
+	}
+	return name, "", true
+}
+
+// statementStart reports whether the token at position i starts a statement, which tells an
+// assignment from a comparison.
+func statementStart(tokens []Token, i int) bool {
+	return i == 0 || at(tokens, i-1, statementPrefixes...)
+}
+
+// statusCode reads a literal HTTP status code at position i.
+func statusCode(tokens []Token, i int) (int, bool) {
+	if i >= len(tokens) || tokens[i].Kind != Number {
+		return 0, false
+	}
+	code, err := strconv.Atoi(tokens[i].Text)
+	if err != nil || code < 100 || code > 599 {
+		return 0, false
+	}
+	return code, true
+}
+
+// callArguments splits the argument list that starts with the parenthesis at position open
+// into top-level arguments.
+func callArguments(tokens []Token, open int) [][]Token {
+	var args [][]Token
+	var current []Token
+	depth := 0
+	for i := open + 1; i < len(tokens); i++ {
+		switch {
+		case at(tokens, i, "(", "["):
+			depth++
+		case at(tokens, i, ")", "]"):
+			if depth == 0 {
+				return append(args, current)
+			}
+			depth--
+		case at(tokens, i, ",") && depth == 0:
+			args = append(args, current)
+			current = nil
+			continue
+		}
+		current = append(current, tokens[i])
+	}
+	return append(args, current)
+}
+
+// collectionKey reads a literal key of a collection access at position i: either
+// ["name"] or .Получить("name").
+func collectionKey(tokens []Token, i int) (string, bool) {
+	if at(tokens, i, "[") && i+1 < len(tokens) && tokens[i+1].Kind == String {
+		return tokens[i+1].Text, true
+	}
+	if at(tokens, i, ".") && at(tokens, i+1, getNames...) && at(tokens, i+2, "(") && i+3 < len(tokens) && tokens[i+3].Kind == String {
+		return tokens[i+3].Text, true
+	}
+	return "", false
+}
+
+// at reports whether the token at position i is one of the given identifiers or punctuation marks.
+func at(tokens []Token, i int, texts ...string) bool {
+	if i >= len(tokens) {
+		return false
+	}
+	for _, text := range texts {
+		if isPunct(tokens[i], text) || isKeyword(tokens[i], []string{text}) {
+			return true
+		}
+	}
+	return false
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
@@ -0,0 +1,138 @@
+package bsl
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		handler string
+		want    *Analysis
+	}{
+		{
+			name: "query parameters",
+			source: `
+Функция СписокGET(Запрос)
+	Страница = Запрос.ПараметрыЗапроса.Получить("page");
+	Размер = Запрос.ПараметрыЗапроса["size"];
+	Код = Запрос.ПараметрыURL["Код"];
+	Возврат Новый HTTPСервисОтвет(200);
+КонецФункции`,
+			handler: "СписокGET",
+			want: &Analysis{
+				QueryParams: []string{"page", "size"},
+				URLParams:   []string{"Код"},
+				Responses:   []Response{{Code: 200}},
+			},
+		},
+		{
+			name: "helper call propagation",
+			source: `
+Функция ЗаказыGET(Запрос)
+	Отбор = ПрочитатьОтбор(Запрос, 10);
+	Возврат Ответ(404);
+КонецФункции
+
+Функция ПрочитатьОтбор(ВходящийЗапрос, Знач Лимит)
+	Статус = ВходящийЗапрос.ПараметрыЗапроса.Получить("status");
+	Токен = ВходящийЗапрос.Заголовки.Получить("X-Token");
+	Возврат Новый Структура("Статус", Статус);
+КонецФункции
+
+Функция Ответ(Код)
+	Возврат Новый HTTPСервисОтвет(Код);
+КонецФункции`,
+			handler: "ЗаказыGET",
+			want: &Analysis{
+				QueryParams:    []string{"status"},
+				RequestHeaders: []string{"X-Token"},
+			},
+		},
+		{
+			name: "responses with headers",
+			source: `
+Функция ЗаказPOST(Запрос)
+	Если НЕ Проверить() Тогда
+		Возврат Новый HTTPСервисОтвет(400);
+	КонецЕсли;
+	Ответ = Новый HTTPСервисОтвет(201);
+	Ответ.Заголовки.Вставить("Content-Type", "application/json; charset=utf-8");
+	Ответ.Заголовки["Location"] = АдресЗаказа;
+	Возврат Ответ;
+КонецФункции
+
+Функция Проверить()
+	ВызватьИсключение Новый HTTPСервисОтвет(500);
+КонецФункции`,
+			handler: "ЗаказPOST",
+			want: &Analysis{
+				Responses: []Response{
+					{Code: 201, Headers: []string{"Location"}, ContentTypes: []string{"application/json"}},
+					{Code: 400},
+					{Code: 500},
+				},
+			},
+		},
+		{
+			name: "json body",
+			source: `
+Функция ЗаказPUT(Запрос)
+	Чтение = Новый ЧтениеJSON;
+	Чтение.УстановитьСтроку(Запрос.ПолучитьТелоКакСтроку());
+	Данные = ПрочитатьJSON(Чтение);
+	Возврат Новый HTTPСервисОтвет(204);
+КонецФункции`,
+			handler: "ЗаказPUT",
+			want:    &Analysis{Body: BodyJSON, Responses: []Response{{Code: 204}}},
+		},
+		{
+			name: "text body",
+			source: `
+Function NotePOST(Request)
+	Text = Request.GetBodyAsString();
+	Return New HTTPServiceResponse(200);
+EndFunction`,
+			handler: "NotePOST",
+			want:    &Analysis{Body: BodyText, Responses: []Response{{Code: 200}}},
+		},
+		{
+			name: "binary body",
+			source: `
+Функция ФайлPOST(Запрос)
+	Данные = Запрос.ПолучитьТелоКакДвоичныеДанные();
+	Возврат Новый HTTPСервисОтвет(200);
+КонецФункции`,
+			handler: "ФайлPOST",
+			want:    &Analysis{Body: BodyBinary, Responses: []Response{{Code: 200}}},
+		},
+		{
+			name: "reassigned response variable",
+			source: `
+Функция ОтчетGET(Запрос)
+	Ответ = Новый HTTPСервисОтвет(200);
+	Ответ = СформироватьОтвет();
+	Ответ.Заголовки.Вставить("X-Report", "1");
+	Возврат Ответ;
+КонецФункции`,
+			handler: "ОтчетGET",
+			want:    &Analysis{Responses: []Response{{Code: 200}}},
+		},
+		{
+			name:    "missing handler",
+			source:  "Процедура Служебная()\nКонецПроцедуры",
+			handler: "ВерсияGET",
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseModule(tt.source).Analyze(tt.handler)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Analyze(%q) = %+v, want %+v", tt.handler, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package bsl
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Doc is the structured part of a method doc comment. Only lines starting with an @tag are
+// interpreted; the rest of the comment is free text and is ignored.
+//
+//	// @summary Версия интерфейса
+//	// @description Возвращает версию интерфейса сервиса.
+//	// @param version path string required Версия в числовом виде
+//	// @response 200 #/components/schemas/Version Текущая версия
+//	// @response 404 Версия не поддерживается
+//	// @tag Служебные
+//	// @deprecated
+//	// @operationId getVersion
+type Doc struct {
+	Summary     string
+	Description string
+	OperationID string
+	Deprecated  bool
+	Tags        []string
+	Params      []DocParam
+	Responses   []DocResponse
+}
+
+// DocParam is an @param line: name, location, type, an optional "required" flag and a description.
+type DocParam struct {
+	Name        string
+	In          string
+	Type        string
+	Required    bool
+	Description string
+}
+
+// DocResponse is an @response line: a status code or "default", an optional $ref or type,
+// and a description.
+type DocResponse struct {
+	Code        string
+	Ref         string
+	Type        string
+	Description string
+}
+
+var paramLocations = []string{"path", "query", "header", "cookie"}
+
+// ParseDoc interprets the @tags of a doc comment. It returns nil when the comment has none.
+func ParseDoc(lines []string) *Doc {
+	doc := &Doc{}
+	found := false
+	// description collects the continuation lines of a multi-line @description.
+	var description []string
+	inDescription := false
+
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "@") {
+			if inDescription {
+				description = append(description, line)
+			}
+			continue
+		}
+		inDescription = false
+
+		tag, rest := line[1:], ""
+		if i := strings.IndexFunc(tag, unicode.IsSpace); i >= 0 {
+			tag, rest = tag[:i], strings.TrimSpace(tag[i:])
+		}
+		switch strings.ToLower(tag) {
+		case "summary":
+			doc.Summary = rest
+		case "description":
+			description = []string{rest}
+			inDescription = true
+		case "operationid":
+			doc.OperationID = rest
+		case "deprecated":
+			doc.Deprecated = true
+		case "tag":
+			if rest == "" {
+				continue
+			}
+			doc.Tags = append(doc.Tags, rest)
+		case "param":
+			param, ok := parseDocParam(rest)
+			if !ok {
+				continue
+			}
+			doc.Params = append(doc.Params, param)
+		case "response":
+			response, ok := parseDocResponse(rest)
+			if !ok {
+				continue
+			}
+			doc.Responses = append(doc.Responses, response)
+		default:
+			continue
+		}
+		found = true
+	}
+
+	if !found {
+		return nil
+	}
+	doc.Description = strings.TrimSpace(strings.Join(description, "\n"))
+	return doc
+}
+
+// parseDocParam parses "name in type [required] description".
+func parseDocParam(text string) (DocParam, bool) {
+	fields := strings.Fields(text)
+	if len(fields) < 3 || !containsFold(paramLocations, fields[1]) {
+		return DocParam{}, false
+	}
+	param := DocParam{Name: fields[0], In: strings.ToLower(fields[1]), Type: fields[2]}
+	rest := fields[3:]
+	if len(rest) > 0 && strings.EqualFold(rest[0], "required") {
+		param.Required = true
+		rest = rest[1:]
+	}
+	param.Description = strings.Join(rest, " ")
+	return param, true
+}
+
+// parseDocResponse parses "code [ref|type] description". The second field is taken for a
+// reference when it starts with "#/" and for a type when it is a known type name.
+func parseDocResponse(text string) (DocResponse, bool) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 || !isResponseCode(fields[0]) {
+		return DocResponse{}, false
+	}
+	response := DocResponse{Code: strings.ToUpper(fields[0])}
+	if response.Code == "DEFAULT" {
+		response.Code = "default"
+	}
+	rest := fields[1:]
+	if len(rest) > 0 {
+		switch {
+		case strings.HasPrefix(rest[0], "#/"):
+			response.Ref = rest[0]
+			rest = rest[1:]
+		case isDocType(rest[0]):
+			response.Type = rest[0]
+			rest = rest[1:]
+		}
+	}
+	response.Description = strings.Join(rest, " ")
+	return response, true
+}
+
+// docTypes maps the type names of doc comments to an OpenAPI type and format.
+var docTypes = map[string][2]string{
+	"string":    {"string", ""},
+	"integer":   {"integer", ""},
+	"number":    {"number", ""},
+	"boolean":   {"boolean", ""},
+	"object":    {"object", ""},
+	"array":     {"array", ""},
+	"int32":     {"integer", "int32"},
+	"int64":     {"integer", "int64"},
+	"float":     {"number", "float"},
+	"double":    {"number", "double"},
+	"date":      {"string", "date"},
+	"date-time": {"string", "date-time"},
+	"uuid":      {"string", "uuid"},
+	"byte":      {"string", "byte"},
+	"binary":    {"string", "binary"},
+}
+
+// isDocType reports whether name is a type name known to doc comments.
+func isDocType(name string) bool {
+	_, ok := docTypes[strings.ToLower(name)]
+	return ok
+}
+
+// DocType returns the OpenAPI type and format for a doc comment type name. Unknown names
+// are described as strings.
+func DocType(name string) (string, string) {
+	if t, ok := docTypes[strings.ToLower(name)]; ok {
+		return t[0], t[1]
+	}
+	return "string", ""
+}
+
+func isResponseCode(code string) bool {
+	if strings.EqualFold(code, "default") {
+		return true
+	}
+	if len(code) != 3 || code[0] < '1' || code[0] > '5' {
+		return false
+	}
+	for _, r := range code[1:] {
+		if (r < '0' || r > '9') && r != 'X' && r != 'x' {
+			return false
+		}
+	}
+	return true
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
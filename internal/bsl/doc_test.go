@@ -0,0 +1,121 @@
+package bsl
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDoc(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  *Doc
+	}{
+		{
+			name:  "no tags",
+			lines: []string{"Возвращает версию.", "", "Параметры:", "  Запрос - HTTPСервисЗапрос"},
+			want:  nil,
+		},
+		{
+			name: "all tags",
+			lines: []string{
+				"Свободный текст до тегов.",
+				"@summary Версия интерфейса",
+				"@description Возвращает версию",
+				"  интерфейса сервиса.",
+				"@param version path string Версия в числовом виде",
+				"@param X-Request-ID HEADER uuid required Идентификатор запроса",
+				"@response 200 #/components/schemas/Version Текущая версия",
+				"@response 4xx array Ошибки",
+				"@response default",
+				"@tag Служебные",
+				"@deprecated",
+				"@operationId getVersion",
+			},
+			want: &Doc{
+				Summary:     "Версия интерфейса",
+				Description: "Возвращает версию\nинтерфейса сервиса.",
+				OperationID: "getVersion",
+				Deprecated:  true,
+				Tags:        []string{"Служебные"},
+				Params: []DocParam{
+					{Name: "version", In: "path", Type: "string", Description: "Версия в числовом виде"},
+					{Name: "X-Request-ID", In: "header", Type: "uuid", Required: true, Description: "Идентификатор запроса"},
+				},
+				Responses: []DocResponse{
+					{Code: "200", Ref: "#/components/schemas/Version", Description: "Текущая версия"},
+					{Code: "4XX", Type: "array", Description: "Ошибки"},
+					{Code: "default"},
+				},
+			},
+		},
+		{
+			name:  "tags are case-insensitive",
+			lines: []string{"@SUMMARY Версия", "@OperationID getVersion", "@Response DEFAULT Ошибка"},
+			want: &Doc{
+				Summary:     "Версия",
+				OperationID: "getVersion",
+				Responses:   []DocResponse{{Code: "default", Description: "Ошибка"}},
+			},
+		},
+		{
+			name:  "response without a type",
+			lines: []string{"@response 404 Версия не поддерживается"},
+			want:  &Doc{Responses: []DocResponse{{Code: "404", Description: "Версия не поддерживается"}}},
+		},
+		{
+			name:  "description ends at the next tag",
+			lines: []string{"@description Первая строка", "вторая строка", "@tag Служебные", "не описание"},
+			want:  &Doc{Description: "Первая строка\nвторая строка", Tags: []string{"Служебные"}},
+		},
+		{
+			name: "malformed lines are ignored",
+			lines: []string{
+				"@param version",
+				"@param version body string",
+				"@response 600 Неизвестный код",
+				"@response OK",
+				"@response",
+				"@tag",
+				"@unknown значение",
+				"@",
+				"email@example.com",
+			},
+			want: nil,
+		},
+		{
+			name:  "malformed lines next to valid ones",
+			lines: []string{"@param id query", "@param id query integer", "@response 20 Успех"},
+			want:  &Doc{Params: []DocParam{{Name: "id", In: "query", Type: "integer"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseDoc(tt.lines); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseDoc() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocType(t *testing.T) {
+	tests := []struct {
+		name   string
+		typ    string
+		format string
+	}{
+		{"string", "string", ""},
+		{"Integer", "integer", ""},
+		{"array", "array", ""},
+		{"int64", "integer", "int64"},
+		{"double", "number", "double"},
+		{"date-time", "string", "date-time"},
+		{"binary", "string", "binary"},
+		{"Строка", "string", ""},
+	}
+	for _, tt := range tests {
+		if typ, format := DocType(tt.name); typ != tt.typ || format != tt.format {
+			t.Errorf("DocType(%q) = %q, %q; want %q, %q", tt.name, typ, format, tt.typ, tt.format)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package bsl
+
+import (
+	"strings"
+	"unicode"
+)
+
+// TokenKind classifies lexical tokens of a BSL module.
+type TokenKind int
+
+const (
+	Ident TokenKind = iota
+	String
+	Number
+	Punct
+)
+
+// Token is a lexical token of a BSL module. String tokens hold the unquoted value.
+type Token struct {
+	Kind TokenKind
+	Text string
+	Line int
+}
+
+// Comment is a `//` comment of a BSL module. Standalone comments occupy a whole line.
+type Comment struct {
+	Text       string
+	Line       int
+	Standalone bool
+}
+
+// Lex splits BSL source into tokens and comments. Preprocessor instructions (#Область, #Если)
+// and annotations (&НаСервере) are skipped.
+func Lex(source string) ([]Token, []Comment) {
+	var tokens []Token
+	var comments []Comment
+	runes := []rune(source)
+	line := 1
+	lineHasCode := false
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == '\n':
+			line++
+			lineHasCode = false
+			i++
+		case unicode.IsSpace(r):
+			i++
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '/':
+			start := i + 2
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			comments = append(comments, Comment{
+				Text:       strings.TrimRight(string(runes[start:i]), "\r"),
+				Line:       line,
+				Standalone: !lineHasCode,
+			})
+		case (r == '#' || r == '&') && !lineHasCode:
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '"':
+			startLine := line
+			value, next, lines := lexString(runes, i)
+			tokens = append(tokens, Token{Kind: String, Text: value, Line: startLine})
+			line += lines
+			lineHasCode = true
+			i = next
+		case r == '\'':
+			// Date literal
+			i++
+			for i < len(runes) && runes[i] != '\'' && runes[i] != '\n' {
+				i++
+			}
+			i++
+			lineHasCode = true
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, Token{Kind: Ident, Text: string(runes[start:i]), Line: line})
+			lineHasCode = true
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, Token{Kind: Number, Text: string(runes[start:i]), Line: line})
+			lineHasCode = true
+		default:
+			tokens = append(tokens, Token{Kind: Punct, Text: string(r), Line: line})
+			lineHasCode = true
+			i++
+		}
+	}
+
+	return tokens, comments
+}
+
+// lexString reads a string literal starting at the opening quote. Doubled quotes stand for
+// a quote; a literal may continue on the next lines, each starting with `|`.
+// It returns the value, the position after the literal and the number of line breaks read.
+func lexString(runes []rune, start int) (string, int, int) {
+	var b strings.Builder
+	lines := 0
+	i := start + 1
+	for i < len(runes) {
+		r := runes[i]
+		switch {
+		case r == '"' && i+1 < len(runes) && runes[i+1] == '"':
+			b.WriteRune('"')
+			i += 2
+		case r == '"':
+			return b.String(), i + 1, lines
+		case r == '\n':
+			b.WriteRune('\n')
+			lines++
+			i++
+			for i < len(runes) && (runes[i] == ' ' || runes[i] == '\t' || runes[i] == '\r') {
+				i++
+			}
+			if i < len(runes) && runes[i] == '|' {
+				i++
+			}
+		default:
+			b.WriteRune(r)
+			i++
+		}
+	}
+	return b.String(), i, lines
+}
@@ -0,0 +1,119 @@
+package bsl
+
+import (
+	"bytes"
+	"os"
+	"strings"
+)
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// Module is a parsed BSL module.
+type Module struct {
+	Methods []*Method
+}
+
+// Method is a function or a procedure of a BSL module.
+type Method struct {
+	Name   string
+	Params []string
+	// Doc holds the comment block directly above the method, one entry per line.
+	Doc []string
+	// Body holds the tokens between the parameter list and the end keyword.
+	Body []Token
+	Line int
+}
+
+var (
+	methodKeywords    = []string{"Функция", "Процедура", "Function", "Procedure"}
+	methodEndKeywords = []string{"КонецФункции", "КонецПроцедуры", "EndFunction", "EndProcedure"}
+	valKeywords       = []string{"Знач", "Val"}
+)
+
+// ReadModule reads and parses a BSL module file.
+func ReadModule(path string) (*Module, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseModule(string(bytes.TrimPrefix(content, utf8BOM))), nil
+}
+
+// ParseModule splits BSL source into methods.
+func ParseModule(source string) *Module {
+	tokens, comments := Lex(source)
+	module := &Module{}
+
+	for i := 0; i < len(tokens); i++ {
+		if !isKeyword(tokens[i], methodKeywords) || i+2 >= len(tokens) || tokens[i+1].Kind != Ident || !isPunct(tokens[i+2], "(") {
+			continue
+		}
+		method := &Method{Name: tokens[i+1].Text, Line: tokens[i].Line}
+
+		// Parameter list
+		j := i + 3
+		expectName := true
+		for ; j < len(tokens) && !isPunct(tokens[j], ")"); j++ {
+			switch {
+			case isPunct(tokens[j], ","):
+				expectName = true
+			case expectName && tokens[j].Kind == Ident && !isKeyword(tokens[j], valKeywords):
+				method.Params = append(method.Params, tokens[j].Text)
+				expectName = false
+			}
+		}
+
+		// Body
+		start := j + 1
+		for j = start; j < len(tokens) && !isKeyword(tokens[j], methodEndKeywords); j++ {
+		}
+		method.Body = tokens[min(start, len(tokens)):j]
+		method.Doc = docComment(comments, method.Line)
+		module.Methods = append(module.Methods, method)
+		i = j
+	}
+
+	return module
+}
+
+// Method returns the method with the given name; BSL names are case-insensitive.
+func (m *Module) Method(name string) *Method {
+	for _, method := range m.Methods {
+		if strings.EqualFold(method.Name, name) {
+			return method
+		}
+	}
+	return nil
+}
+
+// docComment collects the standalone comment lines directly above the given line.
+func docComment(comments []Comment, line int) []string {
+	var doc []string
+	for i := len(comments) - 1; i >= 0; i-- {
+		c := comments[i]
+		if c.Line >= line {
+			continue
+		}
+		if !c.Standalone || c.Line != line-len(doc)-1 {
+			break
+		}
+		doc = append([]string{c.Text}, doc...)
+	}
+	return doc
+}
+
+func isKeyword(token Token, keywords []string) bool {
+	if token.Kind != Ident {
+		return false
+	}
+	for _, keyword := range keywords {
+		if strings.EqualFold(token.Text, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+func isPunct(token Token, text string) bool {
+	return token.Kind == Punct && token.Text == text
+}
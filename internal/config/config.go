@@ -19,11 +19,31 @@ type Log struct {
 
 // Project структура для хранения настроек проекта
 type Project struct {
-	ConfigurationPath         string `json:"configuration_path"`
-	ExtensionsPath           string `json:"extensions_path"`
-	OutPath                   string `json:"out_path"`
-	SwaggerConfigPath         string `json:"swagger_config_path"`
-	AllServicesConfigFileName string `json:"all_services_config_filename"`
+	ConfigurationPath         string   `json:"configuration_path"`
+	ExtensionsPath            string   `json:"extensions_path"`
+	OutPath                   string   `json:"out_path"`
+	SwaggerConfigPath         string   `json:"swagger_config_path"`
+	AllServicesConfigFileName string   `json:"all_services_config_filename"`
+	WildcardStrategy          string   `json:"wildcard_strategy"`
+	WildcardParamName         string   `json:"wildcard_param_name"`
+	OperationIDStrategy       string   `json:"operation_id_strategy"`
+	Transliteration           string   `json:"transliteration"`
+	Languages                 []string `json:"languages"`
+	LanguageFallback          []string `json:"language_fallback"`
+	SkipBSLAnalysis           bool     `json:"skip_bsl_analysis"`
+	Strict                    bool     `json:"strict"`
+	Validation                string   `json:"validation"`
+	Output                    Output   `json:"output"`
+}
+
+// Output структура для хранения настроек выходных файлов
+type Output struct {
+	// Formats список форматов спецификации: json, yaml
+	Formats []string `json:"formats"`
+	// OpenAPIVersion версия OpenAPI формируемой спецификации: 3.0 или 3.1
+	OpenAPIVersion string `json:"openapi_version"`
+	// Swagger2 дополнительно формирует спецификацию в формате Swagger 2.0 (swagger.json)
+	Swagger2 bool `json:"swagger2"`
 }
 
 // LoadConfig читает и разбирает файл конфигурации
@@ -41,3 +61,48 @@ func LoadConfig(path string) (*Config, error) {
 
 	return &config, nil
 }
+
+// DefaultConfig возвращает настройки по умолчанию для нового проекта
+func DefaultConfig() *Config {
+	return &Config{
+		Log: Log{LogPath: "logs", LogLevel: "INFO"},
+		Project: Project{
+			ConfigurationPath:         "src/cf",
+			ExtensionsPath:            "src/cfe",
+			OutPath:                   "out",
+			SwaggerConfigPath:         "swagger-configs",
+			AllServicesConfigFileName: "all_services.json",
+			WildcardStrategy:          "param",
+			WildcardParamName:         "tail",
+			OperationIDStrategy:       "raw",
+			Languages:                 []string{},
+			LanguageFallback:          []string{},
+			Validation:                "warn",
+			Output:                    Output{Formats: []string{"json"}, OpenAPIVersion: "3.0"},
+		},
+	}
+}
+
+// SaveConfig записывает файл конфигурации
+func SaveConfig(path string, config *Config) error {
+	content, err := json.MarshalIndent(config, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(content, '\n'), 0644)
+}
+
+// LanguageChain возвращает цепочку языков для выбора синонимов: сначала язык спецификации,
+// затем резервные языки
+func (p Project) LanguageChain(language string) []string {
+	var chain []string
+	if language != "" {
+		chain = append(chain, language)
+	}
+	for _, fallback := range p.LanguageFallback {
+		if fallback != language {
+			chain = append(chain, fallback)
+		}
+	}
+	return chain
+}
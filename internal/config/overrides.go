@@ -0,0 +1,142 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Overrides хранит значения настроек проекта, заданные в командной строке.
+// Имя флага совпадает с JSON-именем поля, для вложенных структур через точку
+// (например, -out_path, -output.formats). Списки задаются через запятую.
+type Overrides struct {
+	values map[string]string
+	order  []string
+}
+
+// overrideFlag реализует flag.Value для одного поля Project
+type overrideFlag struct {
+	name      string
+	isBool    bool
+	overrides *Overrides
+}
+
+func (f *overrideFlag) String() string {
+	return ""
+}
+
+func (f *overrideFlag) Set(value string) error {
+	if _, ok := f.overrides.values[f.name]; !ok {
+		f.overrides.order = append(f.overrides.order, f.name)
+	}
+	f.overrides.values[f.name] = value
+	return nil
+}
+
+func (f *overrideFlag) IsBoolFlag() bool {
+	return f.isBool
+}
+
+// RegisterOverrides добавляет в набор флагов по флагу на каждое поле Project
+func RegisterOverrides(flags *flag.FlagSet) *Overrides {
+	overrides := &Overrides{values: make(map[string]string)}
+	registerFields(flags, overrides, reflect.TypeOf(Project{}), "")
+	return overrides
+}
+
+func registerFields(flags *flag.FlagSet, overrides *Overrides, typ reflect.Type, prefix string) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name := jsonName(field)
+		if name == "" {
+			continue
+		}
+		name = prefix + name
+
+		switch field.Type.Kind() {
+		case reflect.Struct:
+			registerFields(flags, overrides, field.Type, name+".")
+			continue
+		case reflect.String, reflect.Bool, reflect.Int:
+		case reflect.Slice:
+			if field.Type.Elem().Kind() != reflect.String {
+				continue
+			}
+		default:
+			continue
+		}
+
+		usage := fmt.Sprintf("Override project.%s of the configuration file", name)
+		if field.Type.Kind() == reflect.Slice {
+			usage += " (comma-separated)"
+		}
+		flags.Var(&overrideFlag{name: name, isBool: field.Type.Kind() == reflect.Bool, overrides: overrides}, name, usage)
+	}
+}
+
+// Apply записывает заданные в командной строке значения в настройки проекта
+func (o *Overrides) Apply(project *Project) error {
+	for _, name := range o.order {
+		field, err := lookupField(reflect.ValueOf(project).Elem(), name)
+		if err != nil {
+			return err
+		}
+		if err := setField(field, o.values[name]); err != nil {
+			return fmt.Errorf("invalid value for -%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func lookupField(value reflect.Value, name string) (reflect.Value, error) {
+	head, rest, nested := strings.Cut(name, ".")
+	for i := 0; i < value.NumField(); i++ {
+		if jsonName(value.Type().Field(i)) != head {
+			continue
+		}
+		if nested {
+			return lookupField(value.Field(i), rest)
+		}
+		return value.Field(i), nil
+	}
+	return reflect.Value{}, fmt.Errorf("unknown project setting %q", name)
+}
+
+func setField(field reflect.Value, value string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(n))
+	case reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		field.Set(reflect.ValueOf(items))
+	}
+	return nil
+}
+
+// jsonName возвращает JSON-имя поля структуры
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"flag"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func parseOverrides(t *testing.T, args ...string) (*Overrides, error) {
+	t.Helper()
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	overrides := RegisterOverrides(flags)
+	return overrides, flags.Parse(args)
+}
+
+func TestOverridesApply(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want func(p *Project)
+	}{
+		{
+			name: "no flags",
+		},
+		{
+			name: "strings",
+			args: []string{"-out_path", "dist", "-operation_id_strategy=translit"},
+			want: func(p *Project) {
+				p.OutPath = "dist"
+				p.OperationIDStrategy = "translit"
+			},
+		},
+		{
+			name: "nested fields",
+			args: []string{"-output.openapi_version", "3.1", "-output.swagger2"},
+			want: func(p *Project) {
+				p.Output.OpenAPIVersion = "3.1"
+				p.Output.Swagger2 = true
+			},
+		},
+		{
+			name: "booleans",
+			args: []string{"-strict", "-skip_bsl_analysis=false", "-output.swagger2=1"},
+			want: func(p *Project) {
+				p.Strict = true
+				p.SkipBSLAnalysis = false
+				p.Output.Swagger2 = true
+			},
+		},
+		{
+			name: "slices",
+			args: []string{"-output.formats", " json, yaml ,", "-languages=ru,en", "-language_fallback", ""},
+			want: func(p *Project) {
+				p.Output.Formats = []string{"json", "yaml"}
+				p.Languages = []string{"ru", "en"}
+				p.LanguageFallback = nil
+			},
+		},
+		{
+			name: "last value wins",
+			args: []string{"-validation", "fail", "-validation", "off"},
+			want: func(p *Project) {
+				p.Validation = "off"
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			overrides, err := parseOverrides(t, tt.args...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := DefaultConfig().Project
+			if err := overrides.Apply(&got); err != nil {
+				t.Fatal(err)
+			}
+			want := DefaultConfig().Project
+			if tt.want != nil {
+				tt.want(&want)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Apply() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestOverridesErrors(t *testing.T) {
+	for _, args := range [][]string{{"-nosuch", "x"}, {"-output.nosuch=x"}, {"-output", "x"}} {
+		if _, err := parseOverrides(t, args...); err == nil {
+			t.Errorf("Parse(%q) error = nil, want an unknown flag", args)
+		}
+	}
+
+	for _, args := range [][]string{{"-strict=maybe"}, {"-output.swagger2=2"}} {
+		overrides, err := parseOverrides(t, args...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		project := DefaultConfig().Project
+		err = overrides.Apply(&project)
+		if err == nil || !strings.Contains(err.Error(), "invalid value for") {
+			t.Errorf("Apply(%q) error = %v, want an invalid value", args, err)
+		}
+	}
+}
+
+func TestSetFieldInt(t *testing.T) {
+	var settings struct {
+		Limit int
+	}
+	field := reflect.ValueOf(&settings).Elem().Field(0)
+	if err := setField(field, "42"); err != nil || settings.Limit != 42 {
+		t.Errorf("setField(42) = %v, Limit = %d", err, settings.Limit)
+	}
+	if err := setField(field, "4x"); err == nil {
+		t.Error("setField(4x) error = nil")
+	}
+}
@@ -0,0 +1,448 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Kind classifies a change by its effect on API consumers.
+type Kind string
+
+const (
+	// Breaking changes can make existing clients fail.
+	Breaking Kind = "breaking"
+	// NonBreaking changes are compatible with existing clients.
+	NonBreaking Kind = "non-breaking"
+)
+
+// Change is a difference between two specifications. Operation is "METHOD /path", or the path
+// alone for changes of a whole path; Location points inside the operation.
+type Change struct {
+	Kind      Kind   `json:"kind"`
+	Operation string `json:"operation"`
+	Location  string `json:"location,omitempty"`
+	Message   string `json:"message"`
+}
+
+func (c Change) String() string {
+	location := c.Operation
+	if c.Location != "" {
+		location += " " + c.Location
+	}
+	return fmt.Sprintf("%s: %s: %s", c.Kind, location, c.Message)
+}
+
+// HasBreaking reports whether any of the changes is breaking.
+func HasBreaking(changes []Change) bool {
+	for _, change := range changes {
+		if change.Kind == Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// methods are the operations of a path item in the order they are compared.
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace", "connect", "merge", "propfind", "proppatch", "move", "copy", "lock", "unlock", "mkcol"}
+
+// Compare compares two OpenAPI documents in JSON form: base is the published version,
+// revision the new one. Changes are returned in path and method order. Paths are matched with
+// their placeholders in place of the parameter names, so renaming a path parameter does not
+// remove the path.
+func Compare(base, revision []byte) ([]Change, error) {
+	var baseDoc, revisionDoc map[string]interface{}
+	if err := json.Unmarshal(base, &baseDoc); err != nil {
+		return nil, fmt.Errorf("base: %w", err)
+	}
+	if err := json.Unmarshal(revision, &revisionDoc); err != nil {
+		return nil, fmt.Errorf("revision: %w", err)
+	}
+
+	c := &comparer{base: baseDoc, revision: revisionDoc}
+	basePaths := object(baseDoc["paths"])
+	revisionPaths := object(revisionDoc["paths"])
+	baseKeys, revisionKeys := pathKeys(basePaths), pathKeys(revisionPaths)
+	for _, key := range unionKeys(baseKeys, revisionKeys) {
+		basePath, inBase := baseKeys[key].(string)
+		revisionPath, inRevision := revisionKeys[key].(string)
+		switch {
+		case !inRevision:
+			c.add(Breaking, basePath, "", "path removed")
+		case !inBase:
+			c.add(NonBreaking, revisionPath, "", "path added")
+		default:
+			c.comparePathItem(basePath, revisionPath, object(basePaths[basePath]), object(revisionPaths[revisionPath]))
+		}
+	}
+	return c.changes, nil
+}
+
+// placeholderRe matches the parameter placeholders of a path.
+var placeholderRe = regexp.MustCompile(`\{[^{}/]*\}`)
+
+// pathKeys maps the paths of a paths object by their form with anonymous placeholders:
+// /orders/{id} and /orders/{orderId} have the same key. Paths that differ only by the names of
+// their placeholders are ambiguous; all but the first of them are keyed by themselves.
+func pathKeys(paths map[string]interface{}) map[string]interface{} {
+	keys := make(map[string]interface{}, len(paths))
+	for _, path := range sortedKeys(paths) {
+		key := placeholderRe.ReplaceAllString(path, "{}")
+		if _, taken := keys[key]; taken {
+			key = path
+		}
+		keys[key] = path
+	}
+	return keys
+}
+
+// placeholderRenames maps the placeholder names of the base path to the names at the same
+// positions of the revision path, for the placeholders that were renamed.
+func placeholderRenames(basePath, revisionPath string) map[string]string {
+	baseNames := placeholderRe.FindAllString(basePath, -1)
+	revisionNames := placeholderRe.FindAllString(revisionPath, -1)
+	renames := make(map[string]string)
+	for i := range baseNames {
+		if i < len(revisionNames) && baseNames[i] != revisionNames[i] {
+			renames[strings.Trim(baseNames[i], "{}")] = strings.Trim(revisionNames[i], "{}")
+		}
+	}
+	return renames
+}
+
+type comparer struct {
+	base     map[string]interface{}
+	revision map[string]interface{}
+	changes  []Change
+	// operation is the operation being compared.
+	operation string
+}
+
+func (c *comparer) add(kind Kind, operation, location, format string, args ...interface{}) {
+	c.changes = append(c.changes, Change{Kind: kind, Operation: operation, Location: location, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *comparer) change(kind Kind, location, format string, args ...interface{}) {
+	c.add(kind, c.operation, location, format, args...)
+}
+
+// comparePathItem compares the operations of a path; operations are named after the
+// revision path.
+func (c *comparer) comparePathItem(basePath, revisionPath string, baseItem, revisionItem map[string]interface{}) {
+	renames := placeholderRenames(basePath, revisionPath)
+	for _, placeholder := range placeholderRe.FindAllString(basePath, -1) {
+		if name := strings.Trim(placeholder, "{}"); renames[name] != "" {
+			c.add(NonBreaking, revisionPath, "", "path parameter %s renamed to %s", name, renames[name])
+		}
+	}
+	for _, method := range methods {
+		baseOp, inBase := baseItem[method].(map[string]interface{})
+		revisionOp, inRevision := revisionItem[method].(map[string]interface{})
+		operation := strings.ToUpper(method) + " " + revisionPath
+		switch {
+		case inBase && !inRevision:
+			c.add(Breaking, operation, "", "operation removed")
+		case !inBase && inRevision:
+			c.add(NonBreaking, operation, "", "operation added")
+		case inBase && inRevision:
+			c.operation = operation
+			c.compareOperation(baseItem, revisionItem, baseOp, revisionOp, renames)
+		}
+	}
+}
+
+// compareOperation compares two versions of an operation; renames are the path parameters of
+// the base renamed in the revision.
+func (c *comparer) compareOperation(baseItem, revisionItem, baseOp, revisionOp map[string]interface{}, renames map[string]string) {
+	if baseID, revisionID := baseOp["operationId"], revisionOp["operationId"]; baseID != revisionID {
+		c.change(NonBreaking, "", "operationId changed from %v to %v", baseID, revisionID)
+	}
+	if baseOp["deprecated"] != true && revisionOp["deprecated"] == true {
+		c.change(NonBreaking, "", "operation deprecated")
+	}
+
+	c.compareParameters(
+		c.parameters(c.base, renames, baseItem["parameters"], baseOp["parameters"]),
+		c.parameters(c.revision, nil, revisionItem["parameters"], revisionOp["parameters"]),
+	)
+	c.compareRequestBody(
+		object(resolve(c.base, baseOp["requestBody"])),
+		object(resolve(c.revision, revisionOp["requestBody"])),
+	)
+	c.compareResponses(object(baseOp["responses"]), object(revisionOp["responses"]))
+}
+
+// parameters returns the parameters of an operation, including those of its path item,
+// keyed by "in:name". Renamed path parameters are keyed by their new name.
+func (c *comparer) parameters(doc map[string]interface{}, renames map[string]string, lists ...interface{}) map[string]map[string]interface{} {
+	params := make(map[string]map[string]interface{})
+	for _, list := range lists {
+		items, _ := list.([]interface{})
+		for _, item := range items {
+			param := object(resolve(doc, item))
+			name, _ := param["name"].(string)
+			in, _ := param["in"].(string)
+			if renamed, ok := renames[name]; ok && in == "path" {
+				name = renamed
+			}
+			if name != "" {
+				params[in+":"+name] = param
+			}
+		}
+	}
+	return params
+}
+
+func (c *comparer) compareParameters(baseParams, revisionParams map[string]map[string]interface{}) {
+	keys := make(map[string]interface{})
+	for key := range baseParams {
+		keys[key] = nil
+	}
+	for key := range revisionParams {
+		keys[key] = nil
+	}
+	for _, key := range sortedKeys(keys) {
+		in, name, _ := strings.Cut(key, ":")
+		location := fmt.Sprintf("parameter %s (%s)", name, in)
+		baseParam, inBase := baseParams[key]
+		revisionParam, inRevision := revisionParams[key]
+		switch {
+		case !inRevision:
+			c.change(NonBreaking, location, "parameter removed")
+		case !inBase && revisionParam["required"] == true:
+			c.change(Breaking, location, "required parameter added")
+		case !inBase:
+			c.change(NonBreaking, location, "optional parameter added")
+		default:
+			if baseParam["required"] != true && revisionParam["required"] == true {
+				c.change(Breaking, location, "parameter became required")
+			} else if baseParam["required"] == true && revisionParam["required"] != true {
+				c.change(NonBreaking, location, "parameter became optional")
+			}
+			c.compareSchema(location, baseParam["schema"], revisionParam["schema"], true, nil)
+		}
+	}
+}
+
+func (c *comparer) compareRequestBody(baseBody, revisionBody map[string]interface{}) {
+	const location = "request body"
+	switch {
+	case baseBody == nil && revisionBody == nil:
+		return
+	case baseBody == nil:
+		if revisionBody["required"] == true {
+			c.change(Breaking, location, "required request body added")
+		} else {
+			c.change(NonBreaking, location, "optional request body added")
+		}
+		return
+	case revisionBody == nil:
+		c.change(NonBreaking, location, "request body removed")
+		return
+	}
+	if baseBody["required"] != true && revisionBody["required"] == true {
+		c.change(Breaking, location, "request body became required")
+	}
+	c.compareContent(location, object(baseBody["content"]), object(revisionBody["content"]), true)
+}
+
+func (c *comparer) compareResponses(baseResponses, revisionResponses map[string]interface{}) {
+	for _, code := range unionKeys(baseResponses, revisionResponses) {
+		location := "response " + code
+		baseResponse, inBase := baseResponses[code]
+		revisionResponse, inRevision := revisionResponses[code]
+		switch {
+		case !inRevision && strings.HasPrefix(code, "2"):
+			c.change(Breaking, location, "success response removed")
+		case !inRevision:
+			c.change(NonBreaking, location, "response removed")
+		case !inBase:
+			c.change(NonBreaking, location, "response added")
+		default:
+			c.compareContent(location,
+				object(object(resolve(c.base, baseResponse))["content"]),
+				object(object(resolve(c.revision, revisionResponse))["content"]),
+				false)
+		}
+	}
+}
+
+// compareContent compares media types. A client sends one of the request media types and
+// accepts the response media types it knows, so removing any of them is breaking.
+func (c *comparer) compareContent(location string, baseContent, revisionContent map[string]interface{}, request bool) {
+	for _, mediaType := range unionKeys(baseContent, revisionContent) {
+		baseMedia, inBase := baseContent[mediaType]
+		revisionMedia, inRevision := revisionContent[mediaType]
+		switch {
+		case !inRevision:
+			c.change(Breaking, location, "media type %s removed", mediaType)
+		case !inBase:
+			c.change(NonBreaking, location, "media type %s added", mediaType)
+		default:
+			c.compareSchema(location+" "+mediaType, object(baseMedia)["schema"], object(revisionMedia)["schema"], request, nil)
+		}
+	}
+}
+
+// compareSchema compares the parts of schemas that clients depend on: types, properties and
+// required properties. In requests a new required property is breaking; in responses a
+// removed property is. seen stops the recursion on cyclic references.
+func (c *comparer) compareSchema(location string, baseValue, revisionValue interface{}, request bool, seen map[string]bool) {
+	if baseValue == nil || revisionValue == nil {
+		return
+	}
+	if seen == nil {
+		seen = make(map[string]bool)
+	}
+	baseRef, _ := object(baseValue)["$ref"].(string)
+	revisionRef, _ := object(revisionValue)["$ref"].(string)
+	if baseRef != "" || revisionRef != "" {
+		key := baseRef + "|" + revisionRef
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+	}
+	base := object(resolve(c.base, baseValue))
+	revision := object(resolve(c.revision, revisionValue))
+
+	baseType, baseNullable := schemaType(base)
+	revisionType, revisionNullable := schemaType(revision)
+	if baseType != "" && revisionType != "" && baseType != revisionType {
+		c.change(Breaking, location, "type changed from %s to %s", baseType, revisionType)
+		return
+	}
+	// A client may not expect null in a response and may not send it in a request.
+	switch {
+	case !baseNullable && revisionNullable:
+		c.change(kind(!request), location, "schema became nullable")
+	case baseNullable && !revisionNullable:
+		c.change(kind(request), location, "schema is no longer nullable")
+	}
+	if baseFormat, revisionFormat := base["format"], revision["format"]; baseFormat != nil && revisionFormat != nil && baseFormat != revisionFormat {
+		c.change(Breaking, location, "format changed from %v to %v", baseFormat, revisionFormat)
+	}
+
+	baseRequired := stringSet(base["required"])
+	revisionRequired := stringSet(revision["required"])
+	baseProperties := object(base["properties"])
+	revisionProperties := object(revision["properties"])
+	for _, name := range unionKeys(baseProperties, revisionProperties) {
+		propertyLocation := location + "." + name
+		baseProperty, inBase := baseProperties[name]
+		revisionProperty, inRevision := revisionProperties[name]
+		switch {
+		case !inRevision && !request:
+			c.change(Breaking, propertyLocation, "property removed")
+		case !inRevision:
+			c.change(NonBreaking, propertyLocation, "property removed")
+		case !inBase && request && revisionRequired[name]:
+			c.change(Breaking, propertyLocation, "required property added")
+		case !inBase:
+			c.change(NonBreaking, propertyLocation, "property added")
+		default:
+			if request && !baseRequired[name] && revisionRequired[name] {
+				c.change(Breaking, propertyLocation, "property became required")
+			}
+			if !request && baseRequired[name] && !revisionRequired[name] {
+				c.change(Breaking, propertyLocation, "property became optional")
+			}
+			c.compareSchema(propertyLocation, baseProperty, revisionProperty, request, seen)
+		}
+	}
+
+	c.compareSchema(location+"[]", base["items"], revision["items"], request, seen)
+}
+
+// schemaType returns the type of a schema without null, an array of types (OpenAPI 3.1)
+// joined, and whether the schema allows null: `nullable: true` of OpenAPI 3.0 and a "null"
+// type of OpenAPI 3.1 are the same.
+func schemaType(schema map[string]interface{}) (string, bool) {
+	nullable := schema["nullable"] == true
+	var types []string
+	switch t := schema["type"].(type) {
+	case string:
+		types = []string{t}
+	case []interface{}:
+		for _, item := range t {
+			types = append(types, fmt.Sprint(item))
+		}
+	}
+
+	var notNull []string
+	for _, t := range types {
+		if t == "null" {
+			nullable = true
+		} else {
+			notNull = append(notNull, t)
+		}
+	}
+	sort.Strings(notNull)
+	return strings.Join(notNull, ","), nullable
+}
+
+// kind returns Breaking for a breaking change and NonBreaking otherwise.
+func kind(breaking bool) Kind {
+	if breaking {
+		return Breaking
+	}
+	return NonBreaking
+}
+
+// resolve follows a local reference of the document; other values are returned as is.
+func resolve(doc map[string]interface{}, value interface{}) interface{} {
+	for i := 0; i < 10; i++ {
+		ref, ok := object(value)["$ref"].(string)
+		if !ok || !strings.HasPrefix(ref, "#/") {
+			return value
+		}
+		var current interface{} = doc
+		for _, token := range strings.Split(ref[2:], "/") {
+			token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+			current = object(current)[token]
+		}
+		if current == nil {
+			return value
+		}
+		value = current
+	}
+	return value
+}
+
+func object(value interface{}) map[string]interface{} {
+	o, _ := value.(map[string]interface{})
+	return o
+}
+
+func stringSet(value interface{}) map[string]bool {
+	set := make(map[string]bool)
+	items, _ := value.([]interface{})
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			set[s] = true
+		}
+	}
+	return set
+}
+
+func unionKeys(a, b map[string]interface{}) []string {
+	keys := make(map[string]interface{}, len(a)+len(b))
+	for key := range a {
+		keys[key] = nil
+	}
+	for key := range b {
+		keys[key] = nil
+	}
+	return sortedKeys(keys)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
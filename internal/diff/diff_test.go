@@ -0,0 +1,183 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+)
+
+// document wraps paths and schemas into an OpenAPI document.
+func document(paths, schemas string) []byte {
+	if schemas == "" {
+		schemas = "{}"
+	}
+	return []byte(`{"openapi": "3.0.3", "info": {"title": "API", "version": "1"}, "paths": ` + paths + `, "components": {"schemas": ` + schemas + `}}`)
+}
+
+func TestCompare(t *testing.T) {
+	const ok = `"responses": {"200": {"description": "OK"}}`
+	tests := []struct {
+		name     string
+		base     []byte
+		revision []byte
+		want     []string
+	}{
+		{
+			name:     "identical",
+			base:     document(`{"/a": {"get": {`+ok+`}}}`, ""),
+			revision: document(`{"/a": {"get": {`+ok+`}}}`, ""),
+		},
+		{
+			name:     "paths and operations",
+			base:     document(`{"/a": {"get": {`+ok+`}}, "/b": {"get": {`+ok+`}, "delete": {`+ok+`}}}`, ""),
+			revision: document(`{"/b": {"get": {`+ok+`}, "post": {`+ok+`}}, "/c": {"get": {`+ok+`}}}`, ""),
+			want: []string{
+				"breaking: /a: path removed",
+				"non-breaking: POST /b: operation added",
+				"breaking: DELETE /b: operation removed",
+				"non-breaking: /c: path added",
+			},
+		},
+		{
+			name: "parameters",
+			base: document(`{"/a/{id}": {"parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}}],
+				"get": {"parameters": [
+					{"name": "page", "in": "query", "schema": {"type": "integer"}},
+					{"name": "size", "in": "query", "required": true, "schema": {"type": "integer"}},
+					{"name": "sort", "in": "query", "schema": {"type": "string"}}], `+ok+`}}}`, ""),
+			revision: document(`{"/a/{id}": {"parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "integer"}}],
+				"get": {"operationId": "getA", "deprecated": true, "parameters": [
+					{"name": "page", "in": "query", "required": true, "schema": {"type": "integer"}},
+					{"name": "size", "in": "query", "schema": {"type": "integer"}},
+					{"name": "filter", "in": "query", "schema": {"type": "string"}},
+					{"name": "X-Token", "in": "header", "required": true, "schema": {"type": "string"}}], `+ok+`}}}`, ""),
+			want: []string{
+				"non-breaking: GET /a/{id}: operationId changed from <nil> to getA",
+				"non-breaking: GET /a/{id}: operation deprecated",
+				"breaking: GET /a/{id} parameter X-Token (header): required parameter added",
+				"breaking: GET /a/{id} parameter id (path): type changed from string to integer",
+				"non-breaking: GET /a/{id} parameter filter (query): optional parameter added",
+				"breaking: GET /a/{id} parameter page (query): parameter became required",
+				"non-breaking: GET /a/{id} parameter size (query): parameter became optional",
+				"non-breaking: GET /a/{id} parameter sort (query): parameter removed",
+			},
+		},
+		{
+			name: "request bodies through references",
+			base: document(`{"/a": {"post": {"requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Order"}}, "text/plain": {}}}, `+ok+`},
+				"put": {`+ok+`}}}`,
+				`{"Order": {"type": "object", "required": ["id"], "properties": {"id": {"type": "string"}, "note": {"type": "string"}}}}`),
+			revision: document(`{"/a": {"post": {"requestBody": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Order"}}}}, `+ok+`},
+				"put": {"requestBody": {"required": true, "content": {}}, `+ok+`}}}`,
+				`{"Order": {"type": "object", "required": ["id", "note", "date"], "properties": {"id": {"type": "string", "format": "uuid"}, "note": {"type": "string"}, "date": {"type": "string"}}}}`),
+			want: []string{
+				"breaking: PUT /a request body: required request body added",
+				"breaking: POST /a request body: request body became required",
+				"breaking: POST /a request body application/json.date: required property added",
+				"breaking: POST /a request body application/json.note: property became required",
+				"breaking: POST /a request body: media type text/plain removed",
+			},
+		},
+		{
+			name: "responses",
+			base: document(`{"/a": {"get": {"responses": {
+				"200": {"description": "OK", "content": {"application/json": {"schema": {"type": "object", "required": ["id"], "properties": {"id": {"type": "string"}, "lines": {"type": "array", "items": {"$ref": "#/components/schemas/Line"}}}}}}},
+				"201": {"description": "Created"},
+				"404": {"description": "Not found"}}}}}`,
+				`{"Line": {"type": "object", "properties": {"sum": {"type": "number"}, "next": {"$ref": "#/components/schemas/Line"}}}}`),
+			revision: document(`{"/a": {"get": {"responses": {
+				"200": {"description": "OK", "content": {"application/json": {"schema": {"type": "object", "properties": {"id": {"type": "string"}, "lines": {"type": "array", "items": {"$ref": "#/components/schemas/Line"}}}}}, "application/xml": {}}},
+				"400": {"description": "Bad request"}}}}}`,
+				`{"Line": {"type": "object", "properties": {"next": {"$ref": "#/components/schemas/Line"}}}}`),
+			want: []string{
+				"breaking: GET /a response 200 application/json.id: property became optional",
+				"breaking: GET /a response 200 application/json.lines[].sum: property removed",
+				"non-breaking: GET /a response 200: media type application/xml added",
+				"breaking: GET /a response 201: success response removed",
+				"non-breaking: GET /a response 400: response added",
+				"non-breaking: GET /a response 404: response removed",
+			},
+		},
+		{
+			name: "renamed path parameters",
+			base: document(`{"/orders/{id}/items/{n}": {"parameters": [
+					{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}},
+					{"name": "n", "in": "path", "required": true, "schema": {"type": "integer"}}],
+				"get": {`+ok+`}}}`, ""),
+			revision: document(`{"/orders/{orderId}/items/{n}": {"parameters": [
+					{"name": "orderId", "in": "path", "required": true, "schema": {"type": "string"}},
+					{"name": "n", "in": "path", "required": true, "schema": {"type": "string"}}],
+				"get": {`+ok+`}}}`, ""),
+			want: []string{
+				"non-breaking: /orders/{orderId}/items/{n}: path parameter id renamed to orderId",
+				"breaking: GET /orders/{orderId}/items/{n} parameter n (path): type changed from integer to string",
+			},
+		},
+		{
+			name:     "ambiguous paths",
+			base:     document(`{"/a/{x}": {"get": {`+ok+`}}, "/a/{y}": {"get": {`+ok+`}}}`, ""),
+			revision: document(`{"/a/{x}": {"get": {`+ok+`}}}`, ""),
+			want:     []string{"breaking: /a/{y}: path removed"},
+		},
+		{
+			name: "nullability",
+			base: document(`{"/a": {"post": {
+				"requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/In"}}}},
+				"responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Out"}}}}}}}}`,
+				`{"In": {"type": "object", "properties": {
+					"same": {"type": "string", "nullable": true},
+					"nullable": {"type": "string"},
+					"strict": {"type": "integer", "nullable": true}}},
+				"Out": {"type": "object", "properties": {
+					"same": {"type": "string", "nullable": true},
+					"nullable": {"type": "string"},
+					"strict": {"type": "integer", "nullable": true},
+					"changed": {"type": "string", "nullable": true}}}}`),
+			revision: document(`{"/a": {"post": {
+				"requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/In"}}}},
+				"responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Out"}}}}}}}}`,
+				`{"In": {"type": "object", "properties": {
+					"same": {"type": ["string", "null"]},
+					"nullable": {"type": ["null", "string"]},
+					"strict": {"type": "integer"}}},
+				"Out": {"type": "object", "properties": {
+					"same": {"type": ["null", "string"]},
+					"nullable": {"type": ["string", "null"]},
+					"strict": {"type": "integer"},
+					"changed": {"type": ["integer", "null"]}}}}`),
+			want: []string{
+				"non-breaking: POST /a request body application/json.nullable: schema became nullable",
+				"breaking: POST /a request body application/json.strict: schema is no longer nullable",
+				"breaking: POST /a response 200 application/json.changed: type changed from string to integer",
+				"breaking: POST /a response 200 application/json.nullable: schema became nullable",
+				"non-breaking: POST /a response 200 application/json.strict: schema is no longer nullable",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := Compare(tt.base, tt.revision)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, change := range changes {
+				got = append(got, change.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Compare() changes:\n%q\nwant\n%q", got, tt.want)
+			}
+			if HasBreaking(changes) != (Summarize(changes).Breaking > 0) {
+				t.Errorf("HasBreaking() disagrees with Summarize()")
+			}
+		})
+	}
+}
+
+func TestCompareInvalidJSON(t *testing.T) {
+	if _, err := Compare([]byte("{"), document("{}", "")); err == nil {
+		t.Error("Compare() error = nil for an invalid base")
+	}
+	if _, err := Compare(document("{}", ""), []byte("[")); err == nil {
+		t.Error("Compare() error = nil for an invalid revision")
+	}
+}
@@ -0,0 +1,85 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Summary counts the changes by kind.
+type Summary struct {
+	Breaking    int `json:"breaking"`
+	NonBreaking int `json:"non_breaking"`
+}
+
+// Summarize counts the changes by kind.
+func Summarize(changes []Change) Summary {
+	var summary Summary
+	for _, change := range changes {
+		if change.Kind == Breaking {
+			summary.Breaking++
+		} else {
+			summary.NonBreaking++
+		}
+	}
+	return summary
+}
+
+// WriteText writes one change per line followed by the summary.
+func WriteText(w io.Writer, changes []Change) error {
+	for _, change := range changes {
+		if _, err := fmt.Fprintln(w, change); err != nil {
+			return err
+		}
+	}
+	summary := Summarize(changes)
+	_, err := fmt.Fprintf(w, "%d changes: %d breaking, %d non-breaking\n", len(changes), summary.Breaking, summary.NonBreaking)
+	return err
+}
+
+// WriteJSON writes the changes and the summary as a JSON object.
+func WriteJSON(w io.Writer, changes []Change) error {
+	if changes == nil {
+		changes = []Change{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(struct {
+		Summary Summary  `json:"summary"`
+		Changes []Change `json:"changes"`
+	}{Summarize(changes), changes})
+}
+
+// WriteMarkdown writes the changes as Markdown tables, breaking changes first, for pull
+// request comments and CI summaries.
+func WriteMarkdown(w io.Writer, changes []Change) error {
+	var b strings.Builder
+	summary := Summarize(changes)
+	b.WriteString("## API changes\n\n")
+	fmt.Fprintf(&b, "%d breaking, %d non-breaking.\n", summary.Breaking, summary.NonBreaking)
+	for _, section := range []struct {
+		title string
+		kind  Kind
+	}{{"Breaking changes", Breaking}, {"Non-breaking changes", NonBreaking}} {
+		var rows []Change
+		for _, change := range changes {
+			if change.Kind == section.kind {
+				rows = append(rows, change)
+			}
+		}
+		if len(rows) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n### %s\n\n| Operation | Location | Change |\n| --- | --- | --- |\n", section.title)
+		for _, change := range rows {
+			fmt.Fprintf(&b, "| `%s` | %s | %s |\n", change.Operation, markdownCell(change.Location), markdownCell(change.Message))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func markdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
@@ -0,0 +1,61 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+var reportChanges = []Change{
+	{Kind: NonBreaking, Operation: "GET /a", Location: "response 400", Message: "response added"},
+	{Kind: Breaking, Operation: "POST /a", Location: "parameter a|b (query)", Message: "required parameter added"},
+	{Kind: Breaking, Operation: "/b", Message: "path removed"},
+}
+
+func TestWriteText(t *testing.T) {
+	var b strings.Builder
+	if err := WriteText(&b, reportChanges); err != nil {
+		t.Fatal(err)
+	}
+	want := `non-breaking: GET /a response 400: response added
+breaking: POST /a parameter a|b (query): required parameter added
+breaking: /b: path removed
+3 changes: 2 breaking, 1 non-breaking
+`
+	if b.String() != want {
+		t.Errorf("WriteText() =\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var b strings.Builder
+	if err := WriteJSON(&b, nil); err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+  "summary": {
+    "breaking": 0,
+    "non_breaking": 0
+  },
+  "changes": []
+}
+`
+	if b.String() != want {
+		t.Errorf("WriteJSON(nil) =\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	var b strings.Builder
+	if err := WriteMarkdown(&b, reportChanges); err != nil {
+		t.Fatal(err)
+	}
+	want := "## API changes\n\n2 breaking, 1 non-breaking.\n" +
+		"\n### Breaking changes\n\n| Operation | Location | Change |\n| --- | --- | --- |\n" +
+		"| `POST /a` | parameter a\\|b (query) | required parameter added |\n" +
+		"| `/b` |  | path removed |\n" +
+		"\n### Non-breaking changes\n\n| Operation | Location | Change |\n| --- | --- | --- |\n" +
+		"| `GET /a` | response 400 | response added |\n"
+	if b.String() != want {
+		t.Errorf("WriteMarkdown() =\n%s\nwant\n%s", b.String(), want)
+	}
+}
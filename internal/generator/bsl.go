@@ -0,0 +1,201 @@
+package generator
+
+import (
+	"log/slog"
+	"one_c_swagger/internal/bsl"
+	"one_c_swagger/internal/models"
+	"one_c_swagger/internal/reader"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// bslAnalyzer scans handler modules of HTTP services. Modules are parsed once and cached.
+type bslAnalyzer struct {
+	enabled bool
+	modules map[string]*bsl.Module
+	log     *slog.Logger
+}
+
+func newBSLAnalyzer(enabled bool, log *slog.Logger) *bslAnalyzer {
+	return &bslAnalyzer{
+		enabled: enabled,
+		modules: make(map[string]*bsl.Module),
+		log:     log,
+	}
+}
+
+// analyze returns the analysis of the method handler, or nil when analysis is disabled or
+// the handler cannot be found.
+func (a *bslAnalyzer) analyze(method reader.Method) *bsl.Analysis {
+	if !a.enabled {
+		return nil
+	}
+	module := a.module(method)
+	if module == nil {
+		return nil
+	}
+
+	analysis := module.Analyze(method.Properties.Handler)
+	if analysis == nil {
+		a.log.Warn("Handler not found in module", "handler", method.Properties.Handler, "path", method.ModulePath)
+	}
+	return analysis
+}
+
+// doc returns the structured doc comment of the method handler, or nil when it has none.
+// Doc comments are explicit documentation and are read even when analysis is disabled.
+func (a *bslAnalyzer) doc(method reader.Method) *bsl.Doc {
+	module := a.module(method)
+	if module == nil {
+		return nil
+	}
+	handler := module.Method(method.Properties.Handler)
+	if handler == nil {
+		return nil
+	}
+	return bsl.ParseDoc(handler.Doc)
+}
+
+// module returns the parsed module of the method handler, or nil when it cannot be read.
+func (a *bslAnalyzer) module(method reader.Method) *bsl.Module {
+	if method.ModulePath == "" || method.Properties.Handler == "" {
+		return nil
+	}
+
+	module, ok := a.modules[method.ModulePath]
+	if !ok {
+		var err error
+		module, err = bsl.ReadModule(method.ModulePath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				a.log.Debug("Handler module not found", "path", method.ModulePath)
+			} else {
+				a.log.Warn("Error reading handler module", "path", method.ModulePath, "error", err)
+			}
+		}
+		a.modules[method.ModulePath] = module
+	}
+	return module
+}
+
+// ignoredHeaderParams are header names that OpenAPI does not allow as header parameters.
+var ignoredHeaderParams = []string{"Accept", "Content-Type", "Authorization"}
+
+// addInferredParameters adds the query parameters read by the handler when the operation
+// declares none, and the request headers it reads that the operation does not declare.
+// URL parameters are already generated from the template; those missing from the template
+// are reported.
+func addInferredParameters(params []models.Parameter, analysis *bsl.Analysis, template, location string, log *slog.Logger) []models.Parameter {
+	placeholders := make(map[string]bool)
+	for _, name := range pathParameterNames(template) {
+		placeholders[name] = true
+	}
+	for _, name := range analysis.URLParams {
+		if !placeholders[name] && name != "*" {
+			log.Debug("Handler reads a URL parameter missing from the template", "parameter", name, "operation", location)
+		}
+	}
+
+	hasQuery := false
+	declaredHeaders := make(map[string]bool)
+	for _, param := range params {
+		switch param.In {
+		case "query":
+			hasQuery = true
+		case "header":
+			declaredHeaders[strings.ToLower(param.Name)] = true
+		}
+	}
+	for _, name := range ignoredHeaderParams {
+		declaredHeaders[strings.ToLower(name)] = true
+	}
+
+	if !hasQuery {
+		for _, name := range analysis.QueryParams {
+			params = append(params, inferredParameter(name, "query"))
+		}
+	}
+	for _, name := range analysis.RequestHeaders {
+		if !declaredHeaders[strings.ToLower(name)] {
+			declaredHeaders[strings.ToLower(name)] = true
+			params = append(params, inferredParameter(name, "header"))
+		}
+	}
+	return params
+}
+
+func inferredParameter(name, in string) models.Parameter {
+	return models.Parameter{
+		Name:      name,
+		In:        in,
+		Schema:    &models.Schema{Type: models.Types{"string"}},
+		XInferred: true,
+	}
+}
+
+// addInferredResponses adds a stub for every status code produced by the handler that the
+// operation does not document yet. Headers and media types found in the handler are added to
+// the stubs and to inline responses of the operation that lack them; referenced responses
+// are left untouched.
+func addInferredResponses(responses models.Responses, analysis *bsl.Analysis) {
+	for _, inferred := range analysis.Responses {
+		code := strconv.Itoa(inferred.Code)
+		response, ok := responses[code]
+		if ok {
+			if response == nil || response.Ref != "" {
+				continue
+			}
+		} else {
+			response = &models.Response{Description: statusDescription(code), XInferred: true}
+		}
+
+		for _, name := range inferred.Headers {
+			if response.Headers == nil {
+				response.Headers = make(map[string]models.Header)
+			}
+			if _, ok := response.Headers[name]; !ok {
+				response.Headers[name] = models.Header{Schema: &models.Schema{Type: models.Types{"string"}}}
+			}
+		}
+		for _, contentType := range inferred.ContentTypes {
+			if response.Content == nil {
+				response.Content = make(map[string]models.MediaType)
+			}
+			if _, ok := response.Content[contentType]; !ok {
+				response.Content[contentType] = inferredMediaType(contentType)
+			}
+		}
+		responses[code] = response
+	}
+}
+
+// inferredMediaType describes binary media types as binary strings and leaves others open.
+func inferredMediaType(contentType string) models.MediaType {
+	if contentType == "application/octet-stream" {
+		return models.MediaType{Schema: &models.Schema{Type: models.Types{"string"}, Format: "binary"}}
+	}
+	return models.MediaType{}
+}
+
+// inferredRequestBody describes the request body read by the handler.
+func inferredRequestBody(kind bsl.BodyKind) *models.RequestBody {
+	var contentType string
+	var mediaType models.MediaType
+	switch kind {
+	case bsl.BodyJSON:
+		contentType = "application/json"
+	case bsl.BodyBinary:
+		contentType = "application/octet-stream"
+		mediaType = inferredMediaType(contentType)
+	case bsl.BodyText:
+		contentType = "text/plain"
+		mediaType.Schema = &models.Schema{Type: models.Types{"string"}}
+	default:
+		return nil
+	}
+	return &models.RequestBody{
+		Content:   map[string]models.MediaType{contentType: mediaType},
+		XInferred: true,
+	}
+}
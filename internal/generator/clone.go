@@ -0,0 +1,40 @@
+package generator
+
+import (
+	"encoding/json"
+	"one_c_swagger/internal/reader"
+)
+
+// cloneJSON deep-copies src into dst through JSON. The models keep every field they read, so
+// the copy is complete; pointers shared within src are not shared in dst.
+func cloneJSON(src, dst interface{}) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
+
+// cloneOverlays copies the overlays, which generation updates in place: references are renamed
+// to the schemas of the services and the schemas get x-1c-name. Generating several documents
+// from the same overlays, one per language or on every request of the serve command, then
+// starts from the same state each time.
+func cloneOverlays(configs map[string]*reader.SwaggerConfig, allServicesConfig *reader.AllServicesConfig) (map[string]*reader.SwaggerConfig, *reader.AllServicesConfig, error) {
+	var clonedConfigs map[string]*reader.SwaggerConfig
+	if configs != nil {
+		if err := cloneJSON(configs, &clonedConfigs); err != nil {
+			return nil, nil, err
+		}
+	}
+	if allServicesConfig == nil {
+		return clonedConfigs, nil, nil
+	}
+	clonedAllServices := &reader.AllServicesConfig{}
+	if err := cloneJSON(allServicesConfig, clonedAllServices); err != nil {
+		return nil, nil, err
+	}
+	// An empty defaultResponses attaches no responses, unlike a missing one, and is omitted
+	// from JSON.
+	clonedAllServices.DefaultResponses = allServicesConfig.DefaultResponses
+	return clonedConfigs, clonedAllServices, nil
+}
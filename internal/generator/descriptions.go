@@ -0,0 +1,23 @@
+package generator
+
+import "strings"
+
+// describe builds a description of a 1C object from its synonym and comment.
+// A synonym that merely repeats the object name carries no information and is skipped.
+func describe(name, synonym, comment string) string {
+	if strings.EqualFold(synonym, name) {
+		synonym = ""
+	}
+	return joinDescriptions(synonym, comment)
+}
+
+// joinDescriptions joins the non-empty parts into paragraphs.
+func joinDescriptions(parts ...string) string {
+	var paragraphs []string
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			paragraphs = append(paragraphs, part)
+		}
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
@@ -0,0 +1,130 @@
+package generator
+
+import (
+	"net/http"
+	"one_c_swagger/internal/bsl"
+	"one_c_swagger/internal/models"
+	"strconv"
+	"strings"
+)
+
+// applyDocComment fills the operation from the doc comment of its handler. Whatever the
+// overlay already declares wins: only empty fields, missing parameters and missing responses
+// are taken from the comment. Schema references are resolved in the context of the service,
+// like the references of its overlay.
+func applyDocComment(op *models.Operation, doc *bsl.Doc, serviceName string, localSchemaNames map[string]bool) {
+	if op.Summary == "" {
+		op.Summary = doc.Summary
+	}
+	if op.Description == "" {
+		op.Description = doc.Description
+	}
+	if op.OperationID == "" {
+		op.OperationID = doc.OperationID
+	}
+	op.Deprecated = op.Deprecated || doc.Deprecated
+
+	for _, docParam := range doc.Params {
+		param := models.Parameter{
+			Name:        docParam.Name,
+			In:          docParam.In,
+			Description: docParam.Description,
+			Required:    docParam.Required || docParam.In == "path",
+			Schema:      docTypeSchema(docParam.Type),
+		}
+		i := findParameter(op.Parameters, param.Name, param.In)
+		if i < 0 {
+			op.Parameters = append(op.Parameters, param)
+			continue
+		}
+		existing := &op.Parameters[i]
+		if existing.Description == "" {
+			existing.Description = param.Description
+		}
+		if existing.Schema == nil {
+			existing.Schema = param.Schema
+		}
+		existing.Required = existing.Required || param.Required
+	}
+
+	for _, docResponse := range doc.Responses {
+		if op.Responses == nil {
+			op.Responses = make(models.Responses)
+		}
+		if _, ok := op.Responses[docResponse.Code]; ok {
+			continue
+		}
+		response := docCommentResponse(docResponse)
+		updateRefsInContext(response, serviceName, localSchemaNames)
+		op.Responses[docResponse.Code] = response
+	}
+}
+
+// docCommentResponse builds a response object from an @response line. A reference to
+// #/components/responses is used as is; other references and types describe a JSON body.
+func docCommentResponse(docResponse bsl.DocResponse) *models.Response {
+	if strings.HasPrefix(docResponse.Ref, "#/components/responses/") {
+		return &models.Response{Ref: docResponse.Ref}
+	}
+
+	description := docResponse.Description
+	if description == "" {
+		description = statusDescription(docResponse.Code)
+	}
+	response := &models.Response{Description: description}
+
+	contentType := "application/json"
+	var schema *models.Schema
+	switch {
+	case docResponse.Ref != "":
+		schema = &models.Schema{Ref: docResponse.Ref}
+	case docResponse.Type != "":
+		schema = docTypeSchema(docResponse.Type)
+		if schema.Format == "binary" {
+			contentType = "application/octet-stream"
+		}
+	}
+	if schema != nil {
+		response.Content = map[string]models.MediaType{contentType: {Schema: schema}}
+	}
+	return response
+}
+
+// docTypeSchema describes a type name of a doc comment. The item type of an array cannot be
+// given in a comment, so arrays get an empty items schema that allows any value.
+func docTypeSchema(name string) *models.Schema {
+	typ, format := bsl.DocType(name)
+	schema := &models.Schema{Type: models.Types{typ}, Format: format}
+	if typ == "array" {
+		schema.Items = &models.Schema{}
+	}
+	return schema
+}
+
+// statusDescription returns the standard text of a status code for responses that come
+// without a description.
+func statusDescription(code string) string {
+	if code == "default" {
+		return "Default response"
+	}
+	if status, err := strconv.Atoi(code); err == nil {
+		if text := http.StatusText(status); text != "" {
+			return text
+		}
+	}
+	return "HTTP " + code
+}
+
+// findParameter returns the index of the parameter with the given name and location, or -1.
+// Header names are case-insensitive.
+func findParameter(params []models.Parameter, name, in string) int {
+	for i, param := range params {
+		if param.In != in {
+			continue
+		}
+		if param.Name == name || (in == "header" && strings.EqualFold(param.Name, name)) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,100 @@
+package generator
+
+import (
+	"testing"
+
+	"one_c_swagger/internal/bsl"
+)
+
+func TestApplyDocComment(t *testing.T) {
+	doc := bsl.ParseDoc([]string{
+		"@summary Версия из комментария",
+		"@description Описание из комментария",
+		"@operationId docVersion",
+		"@deprecated",
+		"@tag Служебные",
+		"@param version path string Версия из комментария",
+		"@param ids query array required Идентификаторы",
+		"@param X-Trace header string Трассировка",
+		"@response 200 #/components/schemas/Version Текущая версия",
+		"@response 400 array Ошибки",
+		"@response 404 #/components/responses/NotFound",
+		"@response 500 binary",
+		"@response default",
+	})
+	localSchemas := map[string]bool{"Version": true}
+
+	tests := []struct {
+		name    string
+		overlay string
+		want    string
+	}{
+		{
+			name:    "empty overlay",
+			overlay: `{}`,
+			want: `{
+				"summary": "Версия из комментария",
+				"description": "Описание из комментария",
+				"operationId": "docVersion",
+				"deprecated": true,
+				"parameters": [
+					{"name": "version", "in": "path", "description": "Версия из комментария", "required": true, "schema": {"type": "string"}},
+					{"name": "ids", "in": "query", "description": "Идентификаторы", "required": true, "schema": {"type": "array", "items": {}}},
+					{"name": "X-Trace", "in": "header", "description": "Трассировка", "schema": {"type": "string"}}
+				],
+				"responses": {
+					"200": {"description": "Текущая версия", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Биллинг_Version"}}}},
+					"400": {"description": "Ошибки", "content": {"application/json": {"schema": {"type": "array", "items": {}}}}},
+					"404": {"$ref": "#/components/responses/NotFound"},
+					"500": {"description": "Internal Server Error", "content": {"application/octet-stream": {"schema": {"type": "string", "format": "binary"}}}},
+					"default": {"description": "Default response"}
+				}
+			}`,
+		},
+		{
+			name: "overlay wins",
+			overlay: `{
+				"summary": "Версия из дополнения",
+				"description": "Описание из дополнения",
+				"operationId": "getVersion",
+				"parameters": [
+					{"name": "version", "in": "path", "schema": {"type": "integer"}},
+					{"name": "ids", "in": "query", "description": "Список", "schema": {"type": "string"}},
+					{"name": "x-trace", "in": "header", "description": "Заголовок", "required": true}
+				],
+				"responses": {
+					"200": {"description": "Из дополнения"},
+					"400": {"$ref": "#/components/responses/BadRequest"},
+					"404": {"description": "Не найдено"},
+					"500": {"description": "Ошибка"},
+					"default": {"description": "Ошибка по умолчанию"}
+				}
+			}`,
+			want: `{
+				"summary": "Версия из дополнения",
+				"description": "Описание из дополнения",
+				"operationId": "getVersion",
+				"deprecated": true,
+				"parameters": [
+					{"name": "version", "in": "path", "description": "Версия из комментария", "required": true, "schema": {"type": "integer"}},
+					{"name": "ids", "in": "query", "description": "Список", "required": true, "schema": {"type": "string"}},
+					{"name": "x-trace", "in": "header", "description": "Заголовок", "required": true, "schema": {"type": "string"}}
+				],
+				"responses": {
+					"200": {"description": "Из дополнения"},
+					"400": {"$ref": "#/components/responses/BadRequest"},
+					"404": {"description": "Не найдено"},
+					"500": {"description": "Ошибка"},
+					"default": {"description": "Ошибка по умолчанию"}
+				}
+			}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := parseOperation(t, tt.overlay)
+			applyDocComment(op, doc, "Биллинг", localSchemas)
+			assertJSON(t, op, tt.want)
+		})
+	}
+}
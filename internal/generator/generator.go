@@ -6,46 +6,137 @@ import (
 	"log/slog"
 	"one_c_swagger/internal/models"
 	"one_c_swagger/internal/reader"
+	"reflect"
+	"sort"
 	"strings"
 )
 
-// updateRefsInContext recursively traverses an interface and updates all schema references.
+// updateRefsInContext recursively traverses a value and updates all schema references. The
+// value may be a generic JSON tree or a part of the OpenAPI model.
 func updateRefsInContext(data interface{}, serviceName string, localSchemaNames map[string]bool) {
-	switch v := data.(type) {
-	case map[string]interface{}:
-		for key, val := range v {
-			if key == "$ref" {
-				if refStr, ok := val.(string); ok && strings.HasPrefix(refStr, "#/components/schemas/") {
-					originalName := strings.TrimPrefix(refStr, "#/components/schemas/")
-					if localSchemaNames[originalName] {
-						v["$ref"] = fmt.Sprintf("#/components/schemas/%s_%s", serviceName, originalName)
-					}
+	updateRefs(reflect.ValueOf(data), serviceName, localSchemaNames)
+}
+
+func updateRefs(v reflect.Value, serviceName string, localSchemaNames map[string]bool) {
+	switch v.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		if !v.IsNil() {
+			updateRefs(v.Elem(), serviceName, localSchemaNames)
+		}
+	case reflect.Struct:
+		if v.CanAddr() {
+			if schema, ok := v.Addr().Interface().(*models.Schema); ok {
+				schema.Ref = contextRef(schema.Ref, serviceName, localSchemaNames)
+			}
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				updateRefs(v.Field(i), serviceName, localSchemaNames)
+			}
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			val := v.MapIndex(key)
+			if key.Kind() == reflect.String && key.String() == "$ref" {
+				if refStr, ok := val.Interface().(string); ok {
+					v.SetMapIndex(key, reflect.ValueOf(contextRef(refStr, serviceName, localSchemaNames)).Convert(v.Type().Elem()))
 				}
-			} else {
-				updateRefsInContext(val, serviceName, localSchemaNames)
+				continue
 			}
+			updateRefs(val, serviceName, localSchemaNames)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			updateRefs(v.Index(i), serviceName, localSchemaNames)
+		}
+	}
+}
+
+// contextRef returns the reference to the renamed copy of a schema declared by the service
+// itself; other references are returned as is.
+func contextRef(ref, serviceName string, localSchemaNames map[string]bool) string {
+	if strings.HasPrefix(ref, "#/components/schemas/") {
+		originalName := strings.TrimPrefix(ref, "#/components/schemas/")
+		if localSchemaNames[originalName] {
+			return fmt.Sprintf("#/components/schemas/%s_%s", serviceName, originalName)
+		}
+	}
+	return ref
+}
+
+// serviceNames returns the names of the services followed by the names of the overlays of
+// unknown services, in a stable order.
+func serviceNames(services []reader.HTTPService, configs map[string]*reader.SwaggerConfig) []string {
+	var names []string
+	known := make(map[string]bool)
+	for _, service := range services {
+		names = append(names, service.Properties.Name)
+		known[service.Properties.Name] = true
+	}
+	var overlays []string
+	for name := range configs {
+		if !known[name] {
+			overlays = append(overlays, name)
 		}
-	case []interface{}:
-		for _, item := range v {
-			updateRefsInContext(item, serviceName, localSchemaNames)
+	}
+	sort.Strings(overlays)
+	return append(names, overlays...)
+}
+
+// schemaNames returns the names of the schemas declared by a service overlay.
+func schemaNames(config *reader.SwaggerConfig) map[string]bool {
+	names := make(map[string]bool)
+	if config != nil {
+		for schemaName := range config.Components.Schemas {
+			names[schemaName] = true
 		}
 	}
+	return names
 }
 
-func GenerateOpenAPI(services []reader.HTTPService, configs map[string]*reader.SwaggerConfig, allServicesConfig *reader.AllServicesConfig, log *slog.Logger) (*models.OpenAPI, error) {
+func GenerateOpenAPI(services []reader.HTTPService, configs map[string]*reader.SwaggerConfig, allServicesConfig *reader.AllServicesConfig, configuration *reader.ConfigurationInfo, opts Options, log *slog.Logger) (*models.OpenAPI, error) {
+	if strategy := opts.wildcardStrategy(); strategy != WildcardParam && strategy != WildcardStrip {
+		return nil, fmt.Errorf("unknown wildcard strategy %q", strategy)
+	}
+	if version := opts.openAPIVersion(); version != OpenAPI30 && version != OpenAPI31 {
+		return nil, fmt.Errorf("unknown OpenAPI version %q", version)
+	}
+
+	configs, allServicesConfig, err := cloneOverlays(configs, allServicesConfig)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := newIdentifiers(opts.Transliteration, opts.Strict, log)
+	if err != nil {
+		return nil, err
+	}
+	ids.registerServices(serviceNames(services, configs))
+	operationIDNamer, err := newOperationIDNamer(opts.OperationIDStrategy, ids.scheme)
+	if err != nil {
+		return nil, err
+	}
+	operationIDs := newOperationIDRegistry(opts.Strict, log)
+	operations := newOperationLocations(opts.Strict, log)
+	analyzer := newBSLAnalyzer(opts.AnalyzeBSL, log)
+
 	openapi := &models.OpenAPI{
 		OpenAPI: "3.0.0",
-		Info:    models.Info{Title: "1C HTTP Services", Version: "1.0.0"},
 		Paths:   make(map[string]models.PathItem),
 		Components: models.Components{
-			Schemas:         make(map[string]interface{}),
+			Schemas:         make(map[string]*models.Schema),
 			SecuritySchemes: make(map[string]models.SecurityScheme),
 			Parameters:      make(map[string]models.Parameter),
 			Headers:         make(map[string]models.Header),
-			Responses:       make(map[string]interface{}),
+			Responses:       make(map[string]*models.Response),
 		},
 	}
 
+	var infoOverride *models.Info
+	if allServicesConfig != nil {
+		infoOverride = allServicesConfig.Info
+	}
+	openapi.Info = buildInfo(configuration, infoOverride, opts.Languages)
+
 	// --- PASS 0: Process Global Config ---
 	globalSchemaNames := make(map[string]bool)
 	if allServicesConfig != nil {
@@ -66,13 +157,32 @@ func GenerateOpenAPI(services []reader.HTTPService, configs map[string]*reader.S
 			openapi.Components.Schemas[k] = v
 			globalSchemaNames[k] = true
 		}
+		openapi.Components.Examples = allServicesConfig.Components.Examples
+		openapi.Components.RequestBodies = allServicesConfig.Components.RequestBodies
+		openapi.Components.Links = allServicesConfig.Components.Links
+		openapi.Components.Callbacks = allServicesConfig.Components.Callbacks
+		openapi.Components.Extensions = allServicesConfig.Components.Extensions
+		if len(allServicesConfig.Webhooks) > 0 {
+			if opts.openAPIVersion() == OpenAPI31 {
+				openapi.Webhooks = allServicesConfig.Webhooks
+			} else {
+				log.Warn("Webhooks require OpenAPI 3.1, skipping", "count", len(allServicesConfig.Webhooks))
+			}
+		}
 	}
 
 	// --- PASS 1: Collect and Rename Service-Specific Schemas ---
 	for serviceName, config := range configs {
 		if config.Components.Schemas != nil {
 			for schemaName, schemaData := range config.Components.Schemas {
-				newSchemaName := fmt.Sprintf("%s_%s", serviceName, schemaName)
+				prefix := ids.service(serviceName)
+				newSchemaName := fmt.Sprintf("%s_%s", prefix, schemaName)
+				if schemaData != nil && originalName(serviceName, prefix) != "" {
+					if schemaData.Extensions == nil {
+						schemaData.Extensions = make(map[string]interface{})
+					}
+					schemaData.Extensions["x-1c-name"] = fmt.Sprintf("%s_%s", serviceName, schemaName)
+				}
 				openapi.Components.Schemas[newSchemaName] = schemaData
 			}
 		}
@@ -80,20 +190,23 @@ func GenerateOpenAPI(services []reader.HTTPService, configs map[string]*reader.S
 
 	// --- PASS 2: Update all $refs with context ---
 	for serviceName, config := range configs {
-		localSchemaNames := make(map[string]bool)
-		if config.Components.Schemas != nil {
-			for schemaName := range config.Components.Schemas {
-				localSchemaNames[schemaName] = true
-			}
-		}
-		updateRefsInContext(config.Paths, serviceName, localSchemaNames)
-		updateRefsInContext(config.Components, serviceName, localSchemaNames)
+		localSchemaNames := schemaNames(config)
+		updateRefsInContext(config.Paths, ids.service(serviceName), localSchemaNames)
+		updateRefsInContext(config.Components, ids.service(serviceName), localSchemaNames)
 	}
 
+	operationResponses := defaultResponses(allServicesConfig, openapi.Components.Responses, log)
+
 	// --- PASS 3: Process services and build paths ---
 	for _, service := range services {
-		openapi.Tags = append(openapi.Tags, models.Tag{Name: service.Properties.Name})
+		tagName := ids.service(service.Properties.Name)
+		openapi.Tags = append(openapi.Tags, models.Tag{
+			Name:        tagName,
+			Description: describe(service.Properties.Name, service.Properties.Synonym.Text(opts.Languages...), service.Properties.Comment),
+			XName:       originalName(service.Properties.Name, tagName),
+		})
 		swaggerConfig, hasSwaggerConfig := configs[service.Properties.Name]
+		localSchemaNames := schemaNames(swaggerConfig)
 
 		if hasSwaggerConfig {
 			for name, scheme := range swaggerConfig.Components.SecuritySchemes {
@@ -102,102 +215,127 @@ func GenerateOpenAPI(services []reader.HTTPService, configs map[string]*reader.S
 		}
 
 		for _, urlTemplate := range service.URLTemplates {
-			path := fmt.Sprintf("/%s/%s", strings.Trim(service.Properties.RootURL, "/"), strings.Trim(urlTemplate.Properties.Template, "/"))
+			template, wildcardParam, hasWildcard := translateWildcard(urlTemplate.Properties.Template, opts)
+			if wildcardParam != "" && wildcardParam != opts.wildcardParamName() {
+				log.Warn("Wildcard parameter renamed", "parameter", opts.wildcardParamName(), "renamed", wildcardParam, "template", urlTemplate.Properties.Template)
+			}
+			pathParams := ids.pathParameters(template)
+			path := fmt.Sprintf("/%s/%s", strings.Trim(service.Properties.RootURL, "/"), strings.Trim(pathTemplate(template, pathParams), "/"))
 			pathItem, ok := openapi.Paths[path]
 			if !ok {
 				pathItem = models.PathItem{}
 			}
+			if hasWildcard {
+				pathItem.XWildcard = true
+			}
+			var overlayItem *models.PathItem
+			if hasSwaggerConfig && swaggerConfig.Paths != nil {
+				if pathConfig, ok := swaggerConfig.Paths[urlTemplate.Properties.Template]; ok {
+					overlayItem = overlayPathItem(pathConfig)
+					mergePathItemFields(&pathItem, overlayItem)
+				}
+			}
 
 			for _, method := range urlTemplate.Methods {
 				if strings.ToUpper(method.Properties.HTTPMethod) == "ANY" {
 					pathItem.XAnyMethod = true
 					continue
 				}
+				location := fmt.Sprintf("%s %s", strings.ToUpper(method.Properties.HTTPMethod), path)
+				if !operations.register(location, fmt.Sprintf("%s.%s.%s", service.Properties.Name, urlTemplate.Properties.Name, method.Properties.Name)) {
+					continue
+				}
 
 				// 1. Get the overlay operation from the supplement file
 				var overlayOp *models.Operation
-				if hasSwaggerConfig && swaggerConfig.Paths != nil {
-					if pathConfig, ok := swaggerConfig.Paths[urlTemplate.Properties.Template]; ok {
-						pathConfigBytes, _ := json.Marshal(pathConfig)
-						var pathItemConfig models.PathItem
-						json.Unmarshal(pathConfigBytes, &pathItemConfig)
-						switch strings.ToUpper(method.Properties.HTTPMethod) {
-						case "GET":
-							overlayOp = pathItemConfig.Get
-						case "POST":
-							overlayOp = pathItemConfig.Post
-						case "PUT":
-							overlayOp = pathItemConfig.Put
-						case "DELETE":
-							overlayOp = pathItemConfig.Delete
-						case "HEAD":
-							overlayOp = pathItemConfig.Head
-						case "PATCH":
-							overlayOp = pathItemConfig.Patch
-						case "MERGE":
-							overlayOp = pathItemConfig.Merge
-						case "OPTIONS":
-							overlayOp = pathItemConfig.Options
-						case "TRACE":
-							overlayOp = pathItemConfig.Trace
-						case "CONNECT":
-							overlayOp = pathItemConfig.Connect
-						case "PROPFIND":
-							overlayOp = pathItemConfig.Propfind
-						case "PROPPATCH":
-							overlayOp = pathItemConfig.Proppatch
-						case "MOVE":
-							overlayOp = pathItemConfig.Move
-						case "COPY":
-							overlayOp = pathItemConfig.Copy
-						case "LOCK":
-							overlayOp = pathItemConfig.Lock
-						case "UNLOCK":
-							overlayOp = pathItemConfig.Unlock
-						case "MKCOL":
-							overlayOp = pathItemConfig.Mkcol
-						}
-					}
+				if overlayItem != nil {
+					overlayOp = pathItemOperation(overlayItem, method.Properties.HTTPMethod)
 				}
 				if overlayOp == nil {
 					overlayOp = &models.Operation{}
 				}
+				doc := analyzer.doc(method)
+				if doc != nil {
+					applyDocComment(overlayOp, doc, tagName, localSchemaNames)
+				}
 
 				// 2. Create the final operation and fill from 1C data
 				finalOp := overlayOp
+				if finalOp.Summary == "" {
+					finalOp.Summary = method.Properties.Synonym.Text(opts.Languages...)
+				}
 				if finalOp.Summary == "" {
 					finalOp.Summary = method.Properties.Name
 				}
+				if finalOp.Description == "" {
+					finalOp.Description = joinDescriptions(
+						describe(urlTemplate.Properties.Name, urlTemplate.Properties.Synonym.Text(opts.Languages...), urlTemplate.Properties.Comment),
+						method.Properties.Comment,
+					)
+				}
 				if finalOp.OperationID == "" {
-					finalOp.OperationID = fmt.Sprintf("%s%s", service.Properties.Name, method.Properties.Name)
+					names := []string{service.Properties.Name, urlTemplate.Properties.Name, method.Properties.Name}
+					finalOp.OperationID = operationIDNamer(names...)
+					if finalOp.OperationID != strings.Join(names, "") {
+						finalOp.XName = strings.Join(names, ".")
+					}
+					operationIDs.generate(finalOp, location)
+				} else {
+					operationIDs.declare(finalOp.OperationID, location)
+				}
+				finalOp.Tags = []string{tagName}
+				if doc != nil {
+					for _, tag := range doc.Tags {
+						if tag != tagName {
+							finalOp.Tags = append(finalOp.Tags, tag)
+						}
+					}
+				}
+				if overlayItem != nil {
+					finalOp.Parameters = mergePathItemParameters(finalOp.Parameters, overlayItem.Parameters)
+				}
+				finalOp.Parameters = mergePathParameters(template, finalOp.Parameters, pathParams, location, log)
+				analysis := analyzer.analyze(method)
+				if analysis != nil {
+					finalOp.Parameters = addInferredParameters(finalOp.Parameters, analysis, template, location, log)
+					if finalOp.RequestBody == nil {
+						finalOp.RequestBody = inferredRequestBody(analysis.Body)
+					}
+				}
+				if hasWildcard {
+					if opts.wildcardStrategy() == WildcardStrip {
+						finalOp.Description = wildcardDescription(finalOp.Description, path)
+					} else {
+						markWildcardParameter(finalOp.Parameters, wildcardParam)
+					}
 				}
-				finalOp.Tags = []string{service.Properties.Name}
 
 				// 3. Merge Responses
 				if finalOp.Responses == nil {
 					finalOp.Responses = make(models.Responses)
 				}
-				for code := range openapi.Components.Responses {
+				for code, ref := range operationResponses {
 					if _, ok := finalOp.Responses[code]; !ok {
-						finalOp.Responses[code] = map[string]string{"$ref": fmt.Sprintf("#/components/responses/%s", code)}
+						finalOp.Responses[code] = &models.Response{Ref: ref}
 					}
 				}
+				if analysis != nil {
+					addInferredResponses(finalOp.Responses, analysis)
+				}
 
 				// 4. Polish all non-ref responses with global headers
-				for code, respIntf := range finalOp.Responses {
-					respBytes, _ := json.Marshal(respIntf)
-					var resp models.Response
-					if json.Unmarshal(respBytes, &resp) == nil && !strings.Contains(string(respBytes), "$ref") {
-						if resp.Headers == nil {
-							resp.Headers = make(map[string]interface{})
-						}
-						for name := range openapi.Components.Headers {
-							if _, ok := resp.Headers[name]; !ok {
-								ref := fmt.Sprintf("#/components/headers/%s", name)
-								resp.Headers[name] = map[string]string{"$ref": ref}
-							}
+				for _, resp := range finalOp.Responses {
+					if resp == nil || resp.Ref != "" {
+						continue
+					}
+					if resp.Headers == nil {
+						resp.Headers = make(map[string]models.Header)
+					}
+					for name := range openapi.Components.Headers {
+						if _, ok := resp.Headers[name]; !ok {
+							ref := fmt.Sprintf("#/components/headers/%s", name)
+							resp.Headers[name] = models.Header{Ref: ref}
 						}
-						finalOp.Responses[code] = resp
 					}
 				}
 
@@ -249,11 +387,28 @@ func GenerateOpenAPI(services []reader.HTTPService, configs map[string]*reader.S
 		}
 	}
 
+	operationIDs.resolve()
+
+	if err := operations.err(); err != nil {
+		return nil, err
+	}
+	if err := operationIDs.err(); err != nil {
+		return nil, err
+	}
+	if err := ids.err(); err != nil {
+		return nil, err
+	}
+
+	if opts.openAPIVersion() == OpenAPI31 {
+		return convertToOpenAPI31(openapi)
+	}
+	dropOpenAPI31Info(&openapi.Info, log)
 	return openapi, nil
 }
 
-func ToJSON(openapi *models.OpenAPI) (string, error) {
-	jsonBytes, err := json.MarshalIndent(openapi, "", "  ")
+// ToJSON serialises an OpenAPI or Swagger 2.0 document to indented JSON.
+func ToJSON(document interface{}) (string, error) {
+	jsonBytes, err := json.MarshalIndent(document, "", "  ")
 	if err != nil {
 		return "", err
 	}
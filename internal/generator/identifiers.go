@@ -0,0 +1,127 @@
+package generator
+
+import (
+	"fmt"
+	"log/slog"
+	"one_c_swagger/internal/translit"
+	"strings"
+)
+
+// identifiers synthesises spec identifiers from 1C names, transliterating them when a
+// transliteration scheme is configured. Different 1C names may transliterate alike; within
+// a scope (the services, the placeholders of a URL template) such names get distinct
+// identifiers with a numeric suffix, like duplicate operation ids.
+type identifiers struct {
+	scheme translit.Scheme
+	strict bool
+	log    *slog.Logger
+	// services holds the identifiers of the services, which name tags and prefix schemas.
+	services  identifierScope
+	conflicts []string
+}
+
+// identifierScope maps 1C names to identifiers and back within one scope.
+type identifierScope struct {
+	ids    map[string]string
+	owners map[string]string
+}
+
+func newIdentifierScope() identifierScope {
+	return identifierScope{ids: make(map[string]string), owners: make(map[string]string)}
+}
+
+func newIdentifiers(schemeName string, strict bool, log *slog.Logger) (*identifiers, error) {
+	ids := &identifiers{strict: strict, log: log, services: newIdentifierScope()}
+	if schemeName == "" {
+		return ids, nil
+	}
+	scheme, err := translit.Lookup(schemeName)
+	if err != nil {
+		return nil, err
+	}
+	ids.scheme = scheme
+	return ids, nil
+}
+
+// name returns the identifier for a 1C name, without regard to other names.
+func (ids *identifiers) name(original string) string {
+	if ids.scheme == nil {
+		return original
+	}
+	return translit.Identifier(ids.scheme, original)
+}
+
+// service returns the identifier of a service. Services should be registered in a stable
+// order first, see registerServices, so that suffixes do not depend on map iteration.
+func (ids *identifiers) service(original string) string {
+	return ids.assign(ids.services, original, "service")
+}
+
+// registerServices assigns identifiers to the services in the given order.
+func (ids *identifiers) registerServices(names []string) {
+	for _, name := range names {
+		ids.service(name)
+	}
+}
+
+// pathParameters assigns identifiers to the placeholders of a URL template.
+func (ids *identifiers) pathParameters(template string) map[string]string {
+	scope := newIdentifierScope()
+	for _, name := range pathParameterNames(template) {
+		ids.assign(scope, name, "path parameter of "+template)
+	}
+	return scope.ids
+}
+
+// assign returns the identifier of the 1C name in the scope, giving it one if needed. A
+// conflicting identifier is reported and, outside of strict mode, made unique with a numeric
+// suffix.
+func (ids *identifiers) assign(scope identifierScope, original, kind string) string {
+	if id, ok := scope.ids[original]; ok {
+		return id
+	}
+	id := ids.name(original)
+	if owner, taken := scope.owners[id]; taken {
+		ids.conflicts = append(ids.conflicts, fmt.Sprintf("%q is the %s identifier of %q and %q", id, kind, owner, original))
+		if ids.strict {
+			ids.log.Error("Duplicate identifier", "identifier", id, "kind", kind, "first", owner, "duplicate", original)
+		} else {
+			unique := id
+			for i := 2; ; i++ {
+				unique = fmt.Sprintf("%s%d", id, i)
+				if _, taken := scope.owners[unique]; !taken {
+					break
+				}
+			}
+			ids.log.Warn("Duplicate identifier renamed", "identifier", id, "renamed", unique, "kind", kind, "first", owner, "duplicate", original)
+			id = unique
+		}
+	}
+	scope.ids[original] = id
+	scope.owners[id] = original
+	return id
+}
+
+// err returns an error listing all conflicts found in strict mode.
+func (ids *identifiers) err() error {
+	if !ids.strict || len(ids.conflicts) == 0 {
+		return nil
+	}
+	return fmt.Errorf("duplicate identifiers: %s", strings.Join(ids.conflicts, "; "))
+}
+
+// originalName returns the value of the x-1c-name extension: the 1C name when it differs
+// from the identifier used in the spec, and an empty string otherwise.
+func originalName(original, id string) string {
+	if id == original {
+		return ""
+	}
+	return original
+}
+
+// pathTemplate renames the placeholders of a URL template to their identifiers.
+func pathTemplate(template string, params map[string]string) string {
+	return pathPlaceholderRe.ReplaceAllStringFunc(template, func(placeholder string) string {
+		return "{" + params[placeholder[1:len(placeholder)-1]] + "}"
+	})
+}
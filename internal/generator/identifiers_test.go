@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"io"
+	"log/slog"
+	"reflect"
+	"testing"
+)
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestIdentifiersServices(t *testing.T) {
+	tests := []struct {
+		name     string
+		scheme   string
+		services []string
+		want     []string
+	}{
+		{"no scheme", "", []string{"Счет", "Schet"}, []string{"Счет", "Schet"}},
+		{"transliterated", "simple", []string{"Биллинг", "Склад"}, []string{"Billing", "Sklad"}},
+		{"collision", "simple", []string{"Счет", "Schet", "Schet2"}, []string{"Schet", "Schet2", "Schet22"}},
+		{"empty after transliteration", "simple", []string{"★"}, []string{"u2605"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, err := newIdentifiers(tt.scheme, false, discardLogger())
+			if err != nil {
+				t.Fatal(err)
+			}
+			ids.registerServices(tt.services)
+			var got []string
+			for _, service := range tt.services {
+				got = append(got, ids.service(service))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("service identifiers = %q, want %q", got, tt.want)
+			}
+			if err := ids.err(); err != nil {
+				t.Errorf("err() = %v outside of strict mode", err)
+			}
+		})
+	}
+}
+
+func TestIdentifiersStrict(t *testing.T) {
+	ids, err := newIdentifiers("simple", true, discardLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids.registerServices([]string{"Счет", "Schet"})
+	if err := ids.err(); err == nil {
+		t.Error("err() = nil, want the conflict of Счет and Schet")
+	}
+}
+
+func TestIdentifiersPathParameters(t *testing.T) {
+	ids, err := newIdentifiers("simple", false, discardLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := "/{Код}/{Kod}/{Версия}"
+	params := ids.pathParameters(template)
+	want := map[string]string{"Код": "Kod", "Kod": "Kod2", "Версия": "Versiya"}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("pathParameters(%q) = %v, want %v", template, params, want)
+	}
+	if got := pathTemplate(template, params); got != "/{Kod}/{Kod2}/{Versiya}" {
+		t.Errorf("pathTemplate(%q) = %q", template, got)
+	}
+	if got := originalName("Kod", params["Kod"]); got != "Kod" {
+		t.Errorf("originalName of a renamed parameter = %q, want Kod", got)
+	}
+}
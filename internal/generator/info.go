@@ -0,0 +1,100 @@
+package generator
+
+import (
+	"one_c_swagger/internal/models"
+	"one_c_swagger/internal/reader"
+	"strings"
+)
+
+// Info of a specification generated without a configuration file.
+const (
+	defaultInfoTitle   = "1C HTTP Services"
+	defaultInfoVersion = "1.0.0"
+)
+
+// extensionInfo is an entry of x-1c-extensions: an extension applied to the configuration.
+type extensionInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// buildInfo fills the info object from the properties of the configuration: the synonym
+// (or the name) becomes the title, the brief and detailed information the description and
+// the vendor the contact. The copyright notice, which is not a license, goes to
+// x-1c-copyright, and the applied extensions are listed in x-1c-extensions. The license is
+// only set by the info of the all services config, which is applied on top, field by field.
+func buildInfo(configuration *reader.ConfigurationInfo, override *models.Info, languages []string) models.Info {
+	info := models.Info{Title: defaultInfoTitle, Version: defaultInfoVersion}
+
+	if configuration != nil && configuration.Configuration != nil {
+		properties := configuration.Configuration.Properties
+		if title := properties.Synonym.Text(languages...); title != "" {
+			info.Title = title
+		} else if properties.Name != "" {
+			info.Title = properties.Name
+		}
+		if properties.Version != "" {
+			info.Version = properties.Version
+		}
+		info.Description = joinDescriptions(properties.BriefInformation.Text(languages...), properties.DetailedInformation.Text(languages...))
+
+		vendorURL := properties.VendorInformationAddress.Text(languages...)
+		if properties.Vendor != "" || vendorURL != "" {
+			info.Contact = &models.Contact{Name: properties.Vendor, URL: vendorURL}
+		}
+		if copyright := strings.TrimSpace(properties.Copyright.Text(languages...)); copyright != "" {
+			info.Extensions = map[string]interface{}{"x-1c-copyright": copyright}
+		}
+	}
+
+	if configuration != nil && len(configuration.Extensions) > 0 {
+		extensions := make([]extensionInfo, 0, len(configuration.Extensions))
+		for _, extension := range configuration.Extensions {
+			name := extension.Properties.Name
+			if name == "" {
+				name = extension.Source
+			}
+			extensions = append(extensions, extensionInfo{Name: name, Version: extension.Properties.Version})
+		}
+		if info.Extensions == nil {
+			info.Extensions = make(map[string]interface{})
+		}
+		info.Extensions["x-1c-extensions"] = extensions
+	}
+
+	if override != nil {
+		overrideInfo(&info, override)
+	}
+	return info
+}
+
+// overrideInfo replaces the fields of info that are set in override.
+func overrideInfo(info *models.Info, override *models.Info) {
+	if override.Title != "" {
+		info.Title = override.Title
+	}
+	if override.Summary != "" {
+		info.Summary = override.Summary
+	}
+	if override.Description != "" {
+		info.Description = override.Description
+	}
+	if override.TermsOfService != "" {
+		info.TermsOfService = override.TermsOfService
+	}
+	if override.Contact != nil {
+		info.Contact = override.Contact
+	}
+	if override.License != nil {
+		info.License = override.License
+	}
+	if override.Version != "" {
+		info.Version = override.Version
+	}
+	for key, value := range override.Extensions {
+		if info.Extensions == nil {
+			info.Extensions = make(map[string]interface{})
+		}
+		info.Extensions[key] = value
+	}
+}
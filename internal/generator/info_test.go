@@ -0,0 +1,90 @@
+package generator
+
+import (
+	"testing"
+
+	"one_c_swagger/internal/models"
+	"one_c_swagger/internal/reader"
+)
+
+func localString(items ...string) reader.LocalString {
+	var s reader.LocalString
+	for i := 0; i+1 < len(items); i += 2 {
+		s.Items = append(s.Items, reader.LocalStringItem{Lang: items[i], Content: items[i+1]})
+	}
+	return s
+}
+
+func TestBuildInfo(t *testing.T) {
+	configuration := &reader.Configuration{Properties: reader.ConfigurationProperties{
+		Name:                     "ДемоКонфигурация",
+		Synonym:                  localString("ru", "Демонстрационная конфигурация", "en", "Demo configuration"),
+		BriefInformation:         localString("ru", "HTTP-сервисы"),
+		DetailedInformation:      localString("ru", "Биллинг и передача данных"),
+		Copyright:                localString("ru", ` © ООО "Демо", 2024 `),
+		VendorInformationAddress: localString("ru", "https://example.com"),
+		Vendor:                   `ООО "Демо"`,
+		Version:                  "1.2.3.4",
+	}}
+	extensions := []reader.Configuration{
+		{Source: "_Расширение", Properties: reader.ConfigurationProperties{Name: "ДемоРасширение", Version: "1.0"}},
+		{Source: "_БезСвойств"},
+	}
+
+	tests := []struct {
+		name          string
+		configuration *reader.ConfigurationInfo
+		override      *models.Info
+		languages     []string
+		want          string
+	}{
+		{
+			name: "no configuration",
+			want: `{"title": "1C HTTP Services", "version": "1.0.0"}`,
+		},
+		{
+			name:          "configuration properties",
+			configuration: &reader.ConfigurationInfo{Configuration: configuration, Extensions: extensions},
+			want: `{"title": "Демонстрационная конфигурация",
+				"description": "HTTP-сервисы\n\nБиллинг и передача данных",
+				"contact": {"name": "ООО \"Демо\"", "url": "https://example.com"},
+				"version": "1.2.3.4",
+				"x-1c-copyright": "© ООО \"Демо\", 2024",
+				"x-1c-extensions": [{"name": "ДемоРасширение", "version": "1.0"}, {"name": "_БезСвойств"}]}`,
+		},
+		{
+			name:          "language and name fallback",
+			configuration: &reader.ConfigurationInfo{Configuration: &reader.Configuration{Properties: reader.ConfigurationProperties{Name: "Демо", Synonym: localString("ru", "", "en", "")}}},
+			languages:     []string{"en"},
+			want:          `{"title": "Демо", "version": "1.0.0"}`,
+		},
+		{
+			name:          "language",
+			configuration: &reader.ConfigurationInfo{Configuration: configuration},
+			languages:     []string{"en"},
+			want: `{"title": "Demo configuration",
+				"description": "HTTP-сервисы\n\nБиллинг и передача данных",
+				"contact": {"name": "ООО \"Демо\"", "url": "https://example.com"},
+				"version": "1.2.3.4",
+				"x-1c-copyright": "© ООО \"Демо\", 2024"}`,
+		},
+		{
+			name:          "override",
+			configuration: &reader.ConfigurationInfo{Configuration: configuration},
+			override: &models.Info{Title: "Billing API", License: &models.License{Name: "Proprietary"},
+				Extensions: map[string]interface{}{"x-audience": "partners", "x-1c-copyright": "Demo LLC"}},
+			want: `{"title": "Billing API",
+				"description": "HTTP-сервисы\n\nБиллинг и передача данных",
+				"contact": {"name": "ООО \"Демо\"", "url": "https://example.com"},
+				"license": {"name": "Proprietary"},
+				"version": "1.2.3.4",
+				"x-1c-copyright": "Demo LLC",
+				"x-audience": "partners"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertJSON(t, buildInfo(tt.configuration, tt.override, tt.languages), tt.want)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package generator
+
+import (
+	"log/slog"
+	"one_c_swagger/internal/models"
+	"reflect"
+)
+
+// convertToOpenAPI31 returns the OpenAPI 3.1 version of the generated OpenAPI 3.0 document.
+// The document is copied first: its schemas may be shared with the overlays and with other
+// documents. The document structure is the same; schemas, which come from overlays written
+// for 3.0, are rewritten in JSON Schema 2020-12 terms:
+//   - nullable becomes a "null" type, or an alternative with a null schema where the schema
+//     has no type of its own (references and compositions);
+//   - example becomes examples;
+//   - boolean exclusiveMaximum and exclusiveMinimum become the bounds themselves.
+func convertToOpenAPI31(openapi *models.OpenAPI) (*models.OpenAPI, error) {
+	converted := &models.OpenAPI{}
+	if err := cloneJSON(openapi, converted); err != nil {
+		return nil, err
+	}
+	converted.OpenAPI = "3.1.0"
+	forEachSchema(reflect.ValueOf(converted), convertSchemaTo31)
+	return converted, nil
+}
+
+// dropOpenAPI31Info removes the info fields that only OpenAPI 3.1 defines, info.summary and
+// license.identifier, from an OpenAPI 3.0 document.
+func dropOpenAPI31Info(info *models.Info, log *slog.Logger) {
+	if info.Summary != "" {
+		log.Warn("info.summary requires OpenAPI 3.1, skipping", "summary", info.Summary)
+		info.Summary = ""
+	}
+	if info.License != nil && info.License.Identifier != "" {
+		log.Warn("license.identifier requires OpenAPI 3.1, skipping", "identifier", info.License.Identifier)
+		license := *info.License
+		license.Identifier = ""
+		info.License = &license
+	}
+}
+
+func convertSchemaTo31(schema *models.Schema) {
+	if schema.Nullable {
+		schema.Nullable = false
+		nullSchema := &models.Schema{Type: models.Types{"null"}}
+		switch {
+		case len(schema.Type) > 0:
+			if !schema.Type.Includes("null") {
+				schema.Type = append(schema.Type, "null")
+			}
+			if len(schema.Enum) > 0 && !containsNil(schema.Enum) {
+				schema.Enum = append(schema.Enum, nil)
+			}
+		case schema.Ref != "":
+			schema.AnyOf = []*models.Schema{{Ref: schema.Ref}, nullSchema}
+			schema.Ref = ""
+		case len(schema.OneOf) > 0:
+			schema.OneOf = append(schema.OneOf, nullSchema)
+		case len(schema.AnyOf) > 0:
+			schema.AnyOf = append(schema.AnyOf, nullSchema)
+		case len(schema.AllOf) > 0:
+			schema.AnyOf = []*models.Schema{{AllOf: schema.AllOf}, nullSchema}
+			schema.AllOf = nil
+		}
+	}
+
+	if schema.Example != nil {
+		if len(schema.Examples) == 0 {
+			schema.Examples = []interface{}{schema.Example}
+		}
+		schema.Example = nil
+	}
+
+	schema.ExclusiveMaximum, schema.Maximum = exclusiveBoundTo31(schema.ExclusiveMaximum, schema.Maximum)
+	schema.ExclusiveMinimum, schema.Minimum = exclusiveBoundTo31(schema.ExclusiveMinimum, schema.Minimum)
+}
+
+// exclusiveBoundTo31 moves an exclusive bound into exclusiveMaximum or exclusiveMinimum.
+func exclusiveBoundTo31(exclusive *models.ExclusiveBound, bound *float64) (*models.ExclusiveBound, *float64) {
+	if exclusive == nil || exclusive.Value != nil {
+		return exclusive, bound
+	}
+	if exclusive.Exclusive && bound != nil {
+		return &models.ExclusiveBound{Value: bound}, nil
+	}
+	return nil, bound
+}
+
+func containsNil(values []interface{}) bool {
+	for _, value := range values {
+		if value == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// forEachSchema calls visit for every schema of a part of the model, parents before the
+// schemas they contain.
+func forEachSchema(v reflect.Value, visit func(*models.Schema)) {
+	switch v.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		if v.IsNil() {
+			return
+		}
+		if schema, ok := v.Interface().(*models.Schema); ok {
+			visit(schema)
+		}
+		forEachSchema(v.Elem(), visit)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				forEachSchema(v.Field(i), visit)
+			}
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			forEachSchema(v.MapIndex(key), visit)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			forEachSchema(v.Index(i), visit)
+		}
+	}
+}
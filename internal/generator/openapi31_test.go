@@ -0,0 +1,206 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"one_c_swagger/internal/models"
+	"one_c_swagger/internal/reader"
+)
+
+// parseOpenAPI reads a document written as JSON into the model.
+func parseOpenAPI(t *testing.T, source string) *models.OpenAPI {
+	t.Helper()
+	var openapi models.OpenAPI
+	if err := json.Unmarshal([]byte(source), &openapi); err != nil {
+		t.Fatal(err)
+	}
+	return &openapi
+}
+
+// assertJSON compares the JSON form of a value with the expected JSON, ignoring formatting
+// and key order.
+func assertJSON(t *testing.T, value interface{}, want string) {
+	t.Helper()
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotTree, wantTree interface{}
+	if err := json.Unmarshal(data, &gotTree); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(want), &wantTree); err != nil {
+		t.Fatalf("invalid expected JSON: %v", err)
+	}
+	if !reflect.DeepEqual(gotTree, wantTree) {
+		t.Errorf("got\n%s\nwant\n%s", data, want)
+	}
+}
+
+func TestConvertToOpenAPI31(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		want   string
+	}{
+		{
+			name:   "nullable type",
+			schema: `{"type": "string", "nullable": true, "enum": ["a", "b"]}`,
+			want:   `{"type": ["string", "null"], "enum": ["a", "b", null]}`,
+		},
+		{
+			name:   "nullable reference",
+			schema: `{"$ref": "#/components/schemas/Order", "nullable": true}`,
+			want:   `{"anyOf": [{"$ref": "#/components/schemas/Order"}, {"type": "null"}]}`,
+		},
+		{
+			name:   "nullable composition",
+			schema: `{"allOf": [{"$ref": "#/components/schemas/Order"}], "nullable": true}`,
+			want:   `{"anyOf": [{"allOf": [{"$ref": "#/components/schemas/Order"}]}, {"type": "null"}]}`,
+		},
+		{
+			name:   "nullable alternatives",
+			schema: `{"oneOf": [{"type": "string"}, {"type": "integer"}], "nullable": true}`,
+			want:   `{"oneOf": [{"type": "string"}, {"type": "integer"}, {"type": "null"}]}`,
+		},
+		{
+			name:   "example",
+			schema: `{"type": "integer", "example": 5}`,
+			want:   `{"type": "integer", "examples": [5]}`,
+		},
+		{
+			name:   "exclusive bounds",
+			schema: `{"type": "number", "minimum": 0, "exclusiveMinimum": true, "maximum": 10, "exclusiveMaximum": false}`,
+			want:   `{"type": "number", "exclusiveMinimum": 0, "maximum": 10}`,
+		},
+		{
+			name:   "nested schemas",
+			schema: `{"type": "object", "properties": {"lines": {"type": "array", "items": {"type": "string", "nullable": true}}}, "additionalProperties": {"type": "integer", "example": 1}}`,
+			want:   `{"type": "object", "properties": {"lines": {"type": "array", "items": {"type": ["string", "null"]}}}, "additionalProperties": {"type": "integer", "examples": [1]}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			openapi := parseOpenAPI(t, `{"openapi": "3.0.3", "info": {"title": "API", "version": "1"}, "paths": {},
+				"components": {"schemas": {"S": `+tt.schema+`}}}`)
+			openapi, err := convertToOpenAPI31(openapi)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if openapi.OpenAPI != "3.1.0" {
+				t.Errorf("openapi = %q, want 3.1.0", openapi.OpenAPI)
+			}
+			assertJSON(t, openapi.Components.Schemas["S"], tt.want)
+		})
+	}
+}
+
+// TestConvertToOpenAPI31SharedSchema checks that a schema used in several places is converted
+// everywhere and that the converted document does not share it with the source.
+func TestConvertToOpenAPI31SharedSchema(t *testing.T) {
+	shared := &models.Schema{Type: models.Types{"string"}, Nullable: true}
+	openapi := parseOpenAPI(t, `{"openapi": "3.0.3", "info": {"title": "API", "version": "1"}, "paths": {}}`)
+	openapi.Components.Schemas = map[string]*models.Schema{"A": shared, "B": {Items: shared, Type: models.Types{"array"}}}
+	converted, err := convertToOpenAPI31(openapi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertJSON(t, converted.Components.Schemas, `{
+		"A": {"type": ["string", "null"]},
+		"B": {"type": "array", "items": {"type": ["string", "null"]}}
+	}`)
+	assertJSON(t, shared, `{"type": "string", "nullable": true}`)
+	if openapi.OpenAPI != "3.0.3" {
+		t.Errorf("source version = %s, want 3.0.3", openapi.OpenAPI)
+	}
+}
+
+// TestGenerateLeavesInputsUnchanged generates twice from the same overlays, as the serve
+// command and the generation of several languages do: the overlays must not change and both
+// documents must be the same.
+func TestGenerateLeavesInputsUnchanged(t *testing.T) {
+	var config reader.SwaggerConfig
+	if err := json.Unmarshal([]byte(`{
+		"paths": {"/version": {"get": {"responses": {"200": {"description": "OK",
+			"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Version"}}}}}}}},
+		"components": {"schemas": {"Version": {"type": "object", "nullable": true, "example": {"version": 1},
+			"properties": {"version": {"type": "integer", "minimum": 0, "exclusiveMinimum": true}}}}}
+	}`), &config); err != nil {
+		t.Fatal(err)
+	}
+	var allServices reader.AllServicesConfig
+	if err := json.Unmarshal([]byte(`{
+		"defaultResponses": {},
+		"components": {
+			"schemas": {"Error": {"type": "string", "nullable": true, "example": null}},
+			"responses": {"500": {"description": "Error", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Error"}}}}}
+		}
+	}`), &allServices); err != nil {
+		t.Fatal(err)
+	}
+	configs := map[string]*reader.SwaggerConfig{"Биллинг": &config}
+	services := []reader.HTTPService{testService("Биллинг", "billing", "/version GET")}
+	before, err := json.Marshal([]interface{}{configs, &allServices})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var documents [][]byte
+	for _, opts := range []Options{{OpenAPIVersion: OpenAPI31, Transliteration: "simple"}, {OpenAPIVersion: OpenAPI31, Transliteration: "simple"}} {
+		openapi, err := GenerateOpenAPI(services, configs, &allServices, nil, opts, discardLogger())
+		if err != nil {
+			t.Fatal(err)
+		}
+		document, err := json.Marshal(openapi)
+		if err != nil {
+			t.Fatal(err)
+		}
+		documents = append(documents, document)
+	}
+
+	after, err := json.Marshal([]interface{}{configs, &allServices})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(before, after) {
+		t.Errorf("overlays changed by generation:\n%s\nwant\n%s", after, before)
+	}
+	if allServices.DefaultResponses == nil {
+		t.Error("empty defaultResponses became nil")
+	}
+	if !bytes.Equal(documents[0], documents[1]) {
+		t.Errorf("second document differs:\n%s\nwant\n%s", documents[1], documents[0])
+	}
+	var schemas struct {
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(documents[0], &schemas); err != nil {
+		t.Fatal(err)
+	}
+	assertJSON(t, schemas.Components.Schemas, `{
+		"Error": {"type": ["string", "null"], "examples": [null]},
+		"Billing_Version": {"type": ["object", "null"], "examples": [{"version": 1}], "x-1c-name": "Биллинг_Version",
+			"properties": {"version": {"type": "integer", "exclusiveMinimum": 0}}}
+	}`)
+}
+
+func TestDropOpenAPI31Info(t *testing.T) {
+	license := &models.License{Name: "MIT", Identifier: "MIT"}
+	info := models.Info{Title: "API", Summary: "Summary", Version: "1", License: license}
+	var logs bytes.Buffer
+	dropOpenAPI31Info(&info, slog.New(slog.NewTextHandler(&logs, nil)))
+
+	assertJSON(t, info, `{"title": "API", "version": "1", "license": {"name": "MIT"}}`)
+	if license.Identifier != "MIT" {
+		t.Error("the license of the all services config was modified")
+	}
+	if n := bytes.Count(logs.Bytes(), []byte("level=WARN")); n != 2 {
+		t.Errorf("logged %d warnings, want 2:\n%s", n, logs.String())
+	}
+}
@@ -0,0 +1,194 @@
+package generator
+
+import (
+	"fmt"
+	"log/slog"
+	"one_c_swagger/internal/models"
+	"one_c_swagger/internal/translit"
+	"strings"
+	"unicode"
+)
+
+// Operation id naming strategies.
+const (
+	// OperationIDRaw concatenates the 1C names as they are.
+	OperationIDRaw = "raw"
+	// OperationIDCamelCase joins the words of the 1C names in lowerCamelCase.
+	OperationIDCamelCase = "camelCase"
+	// OperationIDTranslit transliterates the 1C names to ASCII and joins them in lowerCamelCase.
+	// The configured transliteration scheme is used, or the simple scheme when none is set.
+	OperationIDTranslit = "translit"
+)
+
+// operationIDNamer builds an operation id from service, URL template and method names.
+type operationIDNamer func(parts ...string) string
+
+func newOperationIDNamer(strategy string, scheme translit.Scheme) (operationIDNamer, error) {
+	// translate transliterates the parts when a scheme is given.
+	translate := func(scheme translit.Scheme, parts []string) []string {
+		if scheme == nil {
+			return parts
+		}
+		latin := make([]string, len(parts))
+		for i, part := range parts {
+			latin[i] = translit.Identifier(scheme, part)
+		}
+		return latin
+	}
+
+	switch strategy {
+	case "", OperationIDRaw:
+		return func(parts ...string) string {
+			return strings.Join(translate(scheme, parts), "")
+		}, nil
+	case OperationIDCamelCase:
+		return func(parts ...string) string {
+			return camelCase(translate(scheme, parts)...)
+		}, nil
+	case OperationIDTranslit:
+		if scheme == nil {
+			scheme = translit.SimpleScheme
+		}
+		return func(parts ...string) string {
+			return camelCase(translate(scheme, parts)...)
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown operation id strategy %q", strategy)
+}
+
+// camelCase splits the parts into words and joins them in lowerCamelCase.
+func camelCase(parts ...string) string {
+	var b strings.Builder
+	for _, part := range parts {
+		for _, word := range splitWords(part) {
+			runes := []rune(word)
+			if b.Len() == 0 {
+				runes[0] = unicode.ToLower(runes[0])
+			} else {
+				runes[0] = unicode.ToUpper(runes[0])
+			}
+			b.WriteString(string(runes))
+		}
+	}
+	return b.String()
+}
+
+// splitWords splits a name on non-alphanumeric characters and on lower-to-upper case changes,
+// so both `СчетНаОплату` and `setup_result` produce separate words.
+func splitWords(name string) []string {
+	var words []string
+	var current []rune
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, string(current))
+			current = nil
+		}
+	}
+	var prev rune
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			prev = 0
+			continue
+		}
+		if unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev)) {
+			flush()
+		}
+		current = append(current, r)
+		prev = r
+	}
+	flush()
+	return words
+}
+
+// operationIDRegistry keeps operation ids unique across the whole spec. Ids declared in an
+// overlay or a doc comment are registered as they are met and are never renamed; generated ids
+// are resolved after all declared ids are known, so a generated id never takes over an id
+// declared by the user.
+type operationIDRegistry struct {
+	strict    bool
+	used      map[string]string
+	generated []generatedOperationID
+	declared  []string
+	conflicts []string
+	log       *slog.Logger
+}
+
+// generatedOperationID is an operation whose id was built from the 1C names.
+type generatedOperationID struct {
+	op       *models.Operation
+	location string
+}
+
+func newOperationIDRegistry(strict bool, log *slog.Logger) *operationIDRegistry {
+	return &operationIDRegistry{
+		strict: strict,
+		used:   make(map[string]string),
+		log:    log,
+	}
+}
+
+// declare records the explicit id of the operation at the given location. Two operations
+// declaring the same id are always an error: neither of the ids can be changed silently.
+func (r *operationIDRegistry) declare(id, location string) {
+	owner, exists := r.used[id]
+	if !exists {
+		r.used[id] = location
+		return
+	}
+	r.declared = append(r.declared, fmt.Sprintf("%q is declared by %s and %s", id, owner, location))
+	r.log.Error("Duplicate operationId", "operationId", id, "first", owner, "duplicate", location)
+}
+
+// generate queues the operation with a generated id; the id is made unique by resolve.
+func (r *operationIDRegistry) generate(op *models.Operation, location string) {
+	r.generated = append(r.generated, generatedOperationID{op: op, location: location})
+}
+
+// resolve registers the queued generated ids in the order they were added.
+func (r *operationIDRegistry) resolve() {
+	for _, generated := range r.generated {
+		generated.op.OperationID = r.register(generated.op.OperationID, generated.location)
+	}
+	r.generated = nil
+}
+
+// register records the id of the operation at the given location. A conflicting id is
+// reported and, outside of strict mode, made unique with a numeric suffix.
+func (r *operationIDRegistry) register(id, location string) string {
+	owner, exists := r.used[id]
+	if !exists {
+		r.used[id] = location
+		return id
+	}
+
+	r.conflicts = append(r.conflicts, fmt.Sprintf("%q is used by %s and %s", id, owner, location))
+	if r.strict {
+		r.log.Error("Duplicate operationId", "operationId", id, "first", owner, "duplicate", location)
+		return id
+	}
+
+	unique := id
+	for i := 2; ; i++ {
+		unique = fmt.Sprintf("%s%d", id, i)
+		if _, taken := r.used[unique]; !taken {
+			break
+		}
+	}
+	r.used[unique] = location
+	r.log.Warn("Duplicate operationId renamed", "operationId", id, "renamed", unique, "first", owner, "duplicate", location)
+	return unique
+}
+
+// err returns an error listing the duplicate declared ids and, in strict mode, all other
+// conflicts.
+func (r *operationIDRegistry) err() error {
+	conflicts := append([]string(nil), r.declared...)
+	if r.strict {
+		conflicts = append(conflicts, r.conflicts...)
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+	return fmt.Errorf("duplicate operationIds: %s", strings.Join(conflicts, "; "))
+}
@@ -0,0 +1,174 @@
+package generator
+
+import (
+	"reflect"
+	"testing"
+
+	"one_c_swagger/internal/models"
+	"one_c_swagger/internal/reader"
+	"one_c_swagger/internal/translit"
+)
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"СчетНаОплату", []string{"Счет", "На", "Оплату"}},
+		{"setup_result", []string{"setup", "result"}},
+		{"get-v2Data", []string{"get", "v2", "Data"}},
+		{"HTTPСервис", []string{"HTTPСервис"}},
+		{"  ", nil},
+	}
+	for _, tt := range tests {
+		if got := splitWords(tt.name); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitWords(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCamelCase(t *testing.T) {
+	tests := []struct {
+		parts []string
+		want  string
+	}{
+		{[]string{"Биллинг", "СчетНаОплату", "Добавить"}, "биллингСчетНаОплатуДобавить"},
+		{[]string{"test", "get_data", "GET"}, "testGetDataGET"},
+		{[]string{"", "Item"}, "item"},
+	}
+	for _, tt := range tests {
+		if got := camelCase(tt.parts...); got != tt.want {
+			t.Errorf("camelCase(%q) = %q, want %q", tt.parts, got, tt.want)
+		}
+	}
+}
+
+func TestOperationIDNamer(t *testing.T) {
+	parts := []string{"Биллинг", "СчетНаОплату", "Добавить"}
+	tests := []struct {
+		strategy string
+		scheme   translit.Scheme
+		want     string
+	}{
+		{"", nil, "БиллингСчетНаОплатуДобавить"},
+		{OperationIDRaw, nil, "БиллингСчетНаОплатуДобавить"},
+		{OperationIDRaw, translit.SimpleScheme, "BillingSchetNaOplatuDobavit"},
+		{OperationIDCamelCase, nil, "биллингСчетНаОплатуДобавить"},
+		{OperationIDCamelCase, translit.SimpleScheme, "billingSchetNaOplatuDobavit"},
+		{OperationIDTranslit, nil, "billingSchetNaOplatuDobavit"},
+	}
+	for _, tt := range tests {
+		namer, err := newOperationIDNamer(tt.strategy, tt.scheme)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := namer(parts...); got != tt.want {
+			t.Errorf("%q strategy (scheme %v): id = %q, want %q", tt.strategy, tt.scheme != nil, got, tt.want)
+		}
+	}
+
+	if _, err := newOperationIDNamer("snake", nil); err == nil {
+		t.Error("newOperationIDNamer(\"snake\") error = nil")
+	}
+}
+
+func TestOperationIDRegistry(t *testing.T) {
+	// operation is an id of the test, generated unless declared.
+	type operation struct {
+		id       string
+		declared bool
+	}
+	tests := []struct {
+		name       string
+		strict     bool
+		operations []operation
+		want       []string
+		wantError  bool
+	}{
+		{
+			name:       "unique",
+			operations: []operation{{id: "getA"}, {id: "getB", declared: true}},
+			want:       []string{"getA", "getB"},
+		},
+		{
+			name:       "generated duplicates are suffixed",
+			operations: []operation{{id: "getA"}, {id: "getA"}, {id: "getA"}},
+			want:       []string{"getA", "getA2", "getA3"},
+		},
+		{
+			name:       "declared id is kept over an earlier generated one",
+			operations: []operation{{id: "getA"}, {id: "getA", declared: true}},
+			want:       []string{"getA2", "getA"},
+		},
+		{
+			name:       "suffix skips declared ids",
+			operations: []operation{{id: "getA"}, {id: "getA"}, {id: "getA2", declared: true}},
+			want:       []string{"getA", "getA3", "getA2"},
+		},
+		{
+			name:       "two declared ids",
+			operations: []operation{{id: "getA", declared: true}, {id: "getA", declared: true}},
+			want:       []string{"getA", "getA"},
+			wantError:  true,
+		},
+		{
+			name:       "strict",
+			strict:     true,
+			operations: []operation{{id: "getA"}, {id: "getA", declared: true}},
+			want:       []string{"getA", "getA"},
+			wantError:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := newOperationIDRegistry(tt.strict, discardLogger())
+			ops := make([]*models.Operation, len(tt.operations))
+			for i, operation := range tt.operations {
+				ops[i] = &models.Operation{OperationID: operation.id}
+				location := "GET /" + string(rune('a'+i))
+				if operation.declared {
+					registry.declare(operation.id, location)
+				} else {
+					registry.generate(ops[i], location)
+				}
+			}
+			registry.resolve()
+
+			var got []string
+			for _, op := range ops {
+				got = append(got, op.OperationID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("operation ids = %q, want %q", got, tt.want)
+			}
+			if err := registry.err(); (err != nil) != tt.wantError {
+				t.Errorf("err() = %v, want error %t", err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestGenerateOperationXName(t *testing.T) {
+	tests := []struct {
+		opts      Options
+		wantID    string
+		wantXName string
+	}{
+		{Options{}, "БиллингВерсияGET", ""},
+		{Options{OperationIDStrategy: OperationIDCamelCase}, "биллингВерсияGET", "Биллинг.Версия.GET"},
+		{Options{OperationIDStrategy: OperationIDTranslit}, "billingVersiyaGET", "Биллинг.Версия.GET"},
+		{Options{Transliteration: "simple"}, "BillingVersiyaGET", "Биллинг.Версия.GET"},
+	}
+	for _, tt := range tests {
+		service := testService("Биллинг", "bill", "/version GET")
+		service.URLTemplates[0].Properties.Name = "Версия"
+		openapi, err := GenerateOpenAPI([]reader.HTTPService{service}, nil, nil, nil, tt.opts, discardLogger())
+		if err != nil {
+			t.Fatal(err)
+		}
+		op := openapi.Paths["/bill/version"].Get
+		if op.OperationID != tt.wantID || op.XName != tt.wantXName {
+			t.Errorf("%+v: operationId = %q, x-1c-name = %q; want %q, %q", tt.opts, op.OperationID, op.XName, tt.wantID, tt.wantXName)
+		}
+	}
+}
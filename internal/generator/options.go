@@ -0,0 +1,57 @@
+package generator
+
+// Wildcard strategies control how a trailing 1C `*` segment of a URL template is represented.
+const (
+	// WildcardParam converts the `*` tail into a named path parameter marked with x-1c-wildcard.
+	WildcardParam = "param"
+	// WildcardStrip removes the `*` tail from the path and documents it in the operation description.
+	WildcardStrip = "strip"
+)
+
+// OpenAPI versions of the generated document.
+const (
+	// OpenAPI30 produces an OpenAPI 3.0.0 document.
+	OpenAPI30 = "3.0"
+	// OpenAPI31 produces an OpenAPI 3.1.0 document with JSON Schema 2020-12 schemas.
+	OpenAPI31 = "3.1"
+)
+
+const defaultWildcardParamName = "tail"
+
+// Options holds the generator settings.
+type Options struct {
+	WildcardStrategy    string
+	WildcardParamName   string
+	OperationIDStrategy string
+	// Languages is the fallback chain of languages used to pick synonyms, e.g. ["en", "ru"].
+	Languages []string
+	// Transliteration names the translit scheme applied to every identifier synthesised from 1C names.
+	Transliteration string
+	// AnalyzeBSL enables inference of operation details from the handler modules.
+	AnalyzeBSL bool
+	// Strict turns problems that are otherwise only logged, such as duplicate operation ids, into errors.
+	Strict bool
+	// OpenAPIVersion is the version of the generated document: OpenAPI30 (default) or OpenAPI31.
+	OpenAPIVersion string
+}
+
+func (o Options) wildcardStrategy() string {
+	if o.WildcardStrategy == "" {
+		return WildcardParam
+	}
+	return o.WildcardStrategy
+}
+
+func (o Options) wildcardParamName() string {
+	if o.WildcardParamName == "" {
+		return defaultWildcardParamName
+	}
+	return o.WildcardParamName
+}
+
+func (o Options) openAPIVersion() string {
+	if o.OpenAPIVersion == "" {
+		return OpenAPI30
+	}
+	return o.OpenAPIVersion
+}
@@ -0,0 +1,81 @@
+package generator
+
+import (
+	"log/slog"
+	"one_c_swagger/internal/models"
+	"regexp"
+)
+
+// pathPlaceholderRe matches {name} placeholders of a 1C URL template.
+var pathPlaceholderRe = regexp.MustCompile(`\{([^{}/]+)\}`)
+
+// pathParameterNames returns the placeholder names of a URL template in order of appearance.
+func pathParameterNames(template string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, match := range pathPlaceholderRe.FindAllStringSubmatch(template, -1) {
+		name := match[1]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// mergePathParameters builds a required path parameter for every placeholder of the template
+// and merges them with the parameters declared in the overlay. Overlay path parameters with
+// the same name (either the 1C one or the synthesised one) are kept with all their fields,
+// only the name, location, required flag and x-1c-name come from the template; other overlay
+// parameters are kept as is. An overlay path parameter that matches no placeholder would make
+// the path invalid and is skipped with a warning. ids maps the placeholders to their
+// identifiers.
+func mergePathParameters(template string, overlayParams []models.Parameter, ids map[string]string, location string, log *slog.Logger) []models.Parameter {
+	overlayPathParams := make(map[string]models.Parameter)
+	for _, param := range overlayParams {
+		if param.In == "path" {
+			overlayPathParams[param.Name] = param
+		}
+	}
+
+	var params []models.Parameter
+	placeholders := make(map[string]bool)
+	for _, name := range pathParameterNames(template) {
+		param := models.Parameter{
+			Name:     ids[name],
+			In:       "path",
+			Required: true,
+			Schema:   &models.Schema{Type: models.Types{"string"}},
+			XName:    originalName(name, ids[name]),
+		}
+		placeholders[name] = true
+		placeholders[param.Name] = true
+
+		overlayParam, ok := overlayPathParams[name]
+		if !ok {
+			overlayParam, ok = overlayPathParams[param.Name]
+		}
+		if ok {
+			generated := param
+			param = overlayParam
+			param.Name, param.In, param.Required, param.XName = generated.Name, generated.In, generated.Required, generated.XName
+			if param.Schema == nil {
+				param.Schema = generated.Schema
+			}
+		}
+		params = append(params, param)
+	}
+
+	for _, param := range overlayParams {
+		if param.In == "path" {
+			if !placeholders[param.Name] {
+				log.Warn("Path parameter does not occur in the URL template, skipping", "parameter", param.Name, "template", template, "operation", location)
+			}
+			continue
+		}
+		params = append(params, param)
+	}
+
+	return params
+}
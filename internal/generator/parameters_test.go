@@ -0,0 +1,104 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"one_c_swagger/internal/models"
+)
+
+func TestMergePathParameters(t *testing.T) {
+	tests := []struct {
+		name      string
+		template  string
+		scheme    string
+		overlay   string
+		want      string
+		wantWarns int
+	}{
+		{
+			name:     "generated from the template",
+			template: "/bill/{Версия}/{id}",
+			want: `[{"name": "Версия", "in": "path", "required": true, "schema": {"type": "string"}},
+				{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}}]`,
+		},
+		{
+			name:     "overlay fields win, template keeps name, in and required",
+			template: "/bill/{id}",
+			overlay: `[{"name": "id", "in": "path", "required": false, "description": "Номер счета",
+				"schema": {"type": "integer"}, "example": 5, "x-1c-name": "Другое", "x-internal": true}]`,
+			want: `[{"name": "id", "in": "path", "required": true, "description": "Номер счета",
+				"schema": {"type": "integer"}, "example": 5, "x-internal": true}]`,
+		},
+		{
+			name:     "overlay parameter without a schema",
+			template: "/bill/{id}",
+			overlay:  `[{"name": "id", "in": "path", "description": "Номер"}]`,
+			want:     `[{"name": "id", "in": "path", "required": true, "description": "Номер", "schema": {"type": "string"}}]`,
+		},
+		{
+			name:     "overlay matched by the 1C name and by the identifier",
+			template: "/bill/{Версия}/{Код}",
+			scheme:   "simple",
+			overlay: `[{"name": "Версия", "in": "path", "description": "По имени 1С"},
+				{"name": "Kod", "in": "path", "description": "По идентификатору"}]`,
+			want: `[{"name": "Versiya", "in": "path", "required": true, "description": "По имени 1С", "schema": {"type": "string"}, "x-1c-name": "Версия"},
+				{"name": "Kod", "in": "path", "required": true, "description": "По идентификатору", "schema": {"type": "string"}, "x-1c-name": "Код"}]`,
+		},
+		{
+			name:     "other parameters are kept in order",
+			template: "/bill/{id}",
+			overlay: `[{"name": "page", "in": "query", "schema": {"type": "integer"}},
+				{"name": "X-Token", "in": "header", "schema": {"type": "string"}}]`,
+			want: `[{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}},
+				{"name": "page", "in": "query", "schema": {"type": "integer"}},
+				{"name": "X-Token", "in": "header", "schema": {"type": "string"}}]`,
+		},
+		{
+			name:      "overlay path parameter missing from the template",
+			template:  "/bill/{id}",
+			overlay:   `[{"name": "number", "in": "path", "required": true, "schema": {"type": "string"}}]`,
+			want:      `[{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}}]`,
+			wantWarns: 1,
+		},
+		{
+			name:      "template without placeholders",
+			template:  "/version",
+			overlay:   `[{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}}, {"name": "q", "in": "query"}]`,
+			want:      `[{"name": "q", "in": "query"}]`,
+			wantWarns: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var overlay []models.Parameter
+			if tt.overlay != "" {
+				operation := parseOperation(t, `{"parameters": `+tt.overlay+`}`)
+				overlay = operation.Parameters
+			}
+			ids, err := newIdentifiers(tt.scheme, false, discardLogger())
+			if err != nil {
+				t.Fatal(err)
+			}
+			var logs bytes.Buffer
+			params := mergePathParameters(tt.template, overlay, ids.pathParameters(tt.template), "GET "+tt.template, slog.New(slog.NewTextHandler(&logs, nil)))
+			assertJSON(t, params, tt.want)
+			if warns := strings.Count(logs.String(), "level=WARN"); warns != tt.wantWarns {
+				t.Errorf("logged %d warnings, want %d:\n%s", warns, tt.wantWarns, logs.String())
+			}
+		})
+	}
+}
+
+// parseOperation reads an operation written as JSON into the model.
+func parseOperation(t *testing.T, source string) *models.Operation {
+	t.Helper()
+	var operation models.Operation
+	if err := json.Unmarshal([]byte(source), &operation); err != nil {
+		t.Fatal(err)
+	}
+	return &operation
+}
@@ -0,0 +1,139 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"one_c_swagger/internal/models"
+	"strings"
+)
+
+// overlayPathItem decodes a path item of a service overlay.
+func overlayPathItem(pathConfig interface{}) *models.PathItem {
+	pathConfigBytes, _ := json.Marshal(pathConfig)
+	var pathItem models.PathItem
+	json.Unmarshal(pathConfigBytes, &pathItem)
+	return &pathItem
+}
+
+// pathItemOperation returns the operation of the path item for the HTTP method of a 1C method.
+func pathItemOperation(pathItem *models.PathItem, httpMethod string) *models.Operation {
+	switch strings.ToUpper(httpMethod) {
+	case "GET":
+		return pathItem.Get
+	case "POST":
+		return pathItem.Post
+	case "PUT":
+		return pathItem.Put
+	case "DELETE":
+		return pathItem.Delete
+	case "HEAD":
+		return pathItem.Head
+	case "PATCH":
+		return pathItem.Patch
+	case "MERGE":
+		return pathItem.Merge
+	case "OPTIONS":
+		return pathItem.Options
+	case "TRACE":
+		return pathItem.Trace
+	case "CONNECT":
+		return pathItem.Connect
+	case "PROPFIND":
+		return pathItem.Propfind
+	case "PROPPATCH":
+		return pathItem.Proppatch
+	case "MOVE":
+		return pathItem.Move
+	case "COPY":
+		return pathItem.Copy
+	case "LOCK":
+		return pathItem.Lock
+	case "UNLOCK":
+		return pathItem.Unlock
+	case "MKCOL":
+		return pathItem.Mkcol
+	}
+	return nil
+}
+
+// mergePathItemFields copies the path level fields of the overlay (summary, description,
+// servers, extensions) into the generated path item. Path level parameters are merged into
+// the operations instead, see mergePathItemParameters. A $ref to another path item is not
+// supported and is dropped.
+func mergePathItemFields(pathItem *models.PathItem, overlay *models.PathItem) {
+	if pathItem.Summary == "" {
+		pathItem.Summary = overlay.Summary
+	}
+	if pathItem.Description == "" {
+		pathItem.Description = overlay.Description
+	}
+	if pathItem.Servers == nil {
+		pathItem.Servers = overlay.Servers
+	}
+	for key, value := range overlay.Extensions {
+		if pathItem.Extensions == nil {
+			pathItem.Extensions = make(map[string]interface{})
+		}
+		if _, ok := pathItem.Extensions[key]; !ok {
+			pathItem.Extensions[key] = value
+		}
+	}
+}
+
+// mergePathItemParameters adds the path level parameters of the overlay to the parameters of
+// an operation. As in OpenAPI, an operation parameter with the same name and location wins.
+// Keeping them on the operations lets path parameters be matched with the URL template.
+func mergePathItemParameters(params []models.Parameter, pathItemParams []models.Parameter) []models.Parameter {
+	for _, param := range pathItemParams {
+		if findParameter(params, param.Name, param.In) < 0 {
+			params = append(params, param)
+		}
+	}
+	return params
+}
+
+// operationLocations keeps track of the operation at each method and path of the spec.
+// Different URL templates may produce the same path, e.g. `/Price` and `/Price/*` with the
+// strip wildcard strategy, and their methods must not silently replace each other.
+type operationLocations struct {
+	strict    bool
+	owners    map[string]string
+	conflicts []string
+	log       *slog.Logger
+}
+
+func newOperationLocations(strict bool, log *slog.Logger) *operationLocations {
+	return &operationLocations{
+		strict: strict,
+		owners: make(map[string]string),
+		log:    log,
+	}
+}
+
+// register records the 1C method that produces the operation at the location ("GET /path")
+// and reports whether the operation may be added. The method of a location that is already
+// taken is reported and skipped; the first one is kept.
+func (l *operationLocations) register(location, method string) bool {
+	owner, exists := l.owners[location]
+	if !exists {
+		l.owners[location] = method
+		return true
+	}
+
+	l.conflicts = append(l.conflicts, fmt.Sprintf("%s is produced by %s and %s", location, owner, method))
+	if l.strict {
+		l.log.Error("Duplicate operation", "operation", location, "first", owner, "duplicate", method)
+	} else {
+		l.log.Warn("Duplicate operation skipped", "operation", location, "first", owner, "duplicate", method)
+	}
+	return false
+}
+
+// err returns an error listing all conflicts found in strict mode.
+func (l *operationLocations) err() error {
+	if !l.strict || len(l.conflicts) == 0 {
+		return nil
+	}
+	return fmt.Errorf("duplicate operations: %s", strings.Join(l.conflicts, "; "))
+}
@@ -0,0 +1,65 @@
+package generator
+
+import (
+	"log/slog"
+	"one_c_swagger/internal/models"
+	"one_c_swagger/internal/reader"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const componentResponsesPrefix = "#/components/responses/"
+
+// responseCodeRe matches the status code keys of a responses object.
+var responseCodeRe = regexp.MustCompile(`^[1-5](\d\d|XX)$`)
+
+// isResponseKey reports whether key may be used in a responses object.
+func isResponseKey(key string) bool {
+	return key == "default" || responseCodeRe.MatchString(key)
+}
+
+// defaultResponses returns the references attached to every operation, keyed by status code.
+// When all_services.json declares defaultResponses, exactly those are attached. Otherwise the
+// global responses whose names are status codes are attached; other global responses are only
+// available for explicit references.
+func defaultResponses(allServicesConfig *reader.AllServicesConfig, components map[string]*models.Response, log *slog.Logger) map[string]string {
+	refs := make(map[string]string)
+	if allServicesConfig == nil {
+		return refs
+	}
+
+	if allServicesConfig.DefaultResponses == nil {
+		for name := range components {
+			if isResponseKey(name) {
+				refs[name] = componentResponsesPrefix + name
+			}
+		}
+		return refs
+	}
+
+	codes := make([]string, 0, len(allServicesConfig.DefaultResponses))
+	for code := range allServicesConfig.DefaultResponses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		target := allServicesConfig.DefaultResponses[code]
+		if !isResponseKey(code) {
+			log.Warn("Default response key is not a status code or default, skipping", "code", code, "response", target)
+			continue
+		}
+		ref := target
+		if !strings.HasPrefix(ref, "#") {
+			ref = componentResponsesPrefix + target
+		}
+		if name, ok := strings.CutPrefix(ref, componentResponsesPrefix); ok {
+			if _, exists := components[name]; !exists {
+				log.Warn("Default response refers to an unknown component, skipping", "code", code, "response", target)
+				continue
+			}
+		}
+		refs[code] = ref
+	}
+	return refs
+}
@@ -0,0 +1,160 @@
+package generator
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"one_c_swagger/internal/models"
+	"one_c_swagger/internal/reader"
+)
+
+func TestDefaultResponses(t *testing.T) {
+	components := map[string]*models.Response{
+		"NotFound": {Description: "Not found"},
+		"500":      {Description: "Server error"},
+		"4XX":      {Description: "Client error"},
+	}
+	tests := []struct {
+		name     string
+		defaults map[string]string
+		want     map[string]string
+	}{
+		{
+			name: "status code components without defaultResponses",
+			want: map[string]string{"500": "#/components/responses/500", "4XX": "#/components/responses/4XX"},
+		},
+		{
+			name:     "empty defaultResponses",
+			defaults: map[string]string{},
+			want:     map[string]string{},
+		},
+		{
+			name: "names and references",
+			defaults: map[string]string{
+				"404":     "NotFound",
+				"500":     "#/components/responses/500",
+				"default": "#/components/responses/NotFound",
+			},
+			want: map[string]string{
+				"404":     "#/components/responses/NotFound",
+				"500":     "#/components/responses/500",
+				"default": "#/components/responses/NotFound",
+			},
+		},
+		{
+			name: "invalid keys and unknown components are skipped",
+			defaults: map[string]string{
+				"NotFound": "NotFound",
+				"600":      "500",
+				"404":      "Missing",
+				"400":      "#/components/responses/Missing",
+				"5XX":      "500",
+			},
+			want: map[string]string{"5XX": "#/components/responses/500"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &reader.AllServicesConfig{DefaultResponses: tt.defaults}
+			if got := defaultResponses(config, components, discardLogger()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("defaultResponses() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := defaultResponses(nil, components, discardLogger()); len(got) != 0 {
+		t.Errorf("defaultResponses(nil) = %v, want none", got)
+	}
+}
+
+// TestGenerateDefaultResponses checks that default responses only fill the status codes that
+// the overlay does not declare and that inferred stubs never replace a response.
+func TestGenerateDefaultResponses(t *testing.T) {
+	module := filepath.Join(t.TempDir(), "Module.bsl")
+	source := `
+Функция ЗаказGET(Запрос)
+	Если Запрос.ПараметрыURL["id"] = "" Тогда
+		Возврат Новый HTTPСервисОтвет(404);
+	КонецЕсли;
+	Если Ложь Тогда
+		Возврат Новый HTTPСервисОтвет(409);
+	КонецЕсли;
+	Возврат Новый HTTPСервисОтвет(200);
+КонецФункции
+`
+	if err := os.WriteFile(module, []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	service := testService("Заказы", "orders", "/{id} GET")
+	template := &service.URLTemplates[0]
+	template.Methods = append(template.Methods, template.Methods[0])
+	template.Methods[1].Properties.Name = "DELETE"
+	template.Methods[1].Properties.HTTPMethod = "DELETE"
+	for i := range template.Methods {
+		template.Methods[i].Properties.Handler = "ЗаказGET"
+		template.Methods[i].ModulePath = module
+	}
+
+	var config reader.SwaggerConfig
+	overlay := `{"paths": {"/{id}": {"delete": {"responses": {
+		"204": {"description": "Удален"},
+		"404": {"description": "Заказ не найден"},
+		"500": {"$ref": "#/components/responses/NotFound"}
+	}}}}}`
+	if err := json.Unmarshal([]byte(overlay), &config); err != nil {
+		t.Fatal(err)
+	}
+	allServices := &reader.AllServicesConfig{
+		DefaultResponses: map[string]string{"404": "NotFound", "500": "500"},
+		Components: models.Components{Responses: map[string]*models.Response{
+			"NotFound": {Description: "Not found"},
+			"500":      {Description: "Server error"},
+		}},
+	}
+
+	openapi, err := GenerateOpenAPI([]reader.HTTPService{service}, map[string]*reader.SwaggerConfig{"Заказы": &config}, allServices, nil, Options{AnalyzeBSL: true}, discardLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
+	item := openapi.Paths["/orders/{id}"]
+
+	codes := func(responses models.Responses) map[string]string {
+		got := make(map[string]string)
+		for code, response := range responses {
+			switch {
+			case response.Ref != "":
+				got[code] = response.Ref
+			case response.XInferred:
+				got[code] = "inferred"
+			default:
+				got[code] = response.Description
+			}
+		}
+		return got
+	}
+	// The handler of GET is not described by the overlay: defaults are attached for 404 and
+	// 500, and only the codes without a default get an inferred stub.
+	wantGet := map[string]string{
+		"200": "inferred",
+		"404": "#/components/responses/NotFound",
+		"409": "inferred",
+		"500": "#/components/responses/500",
+	}
+	if got := codes(item.Get.Responses); !reflect.DeepEqual(got, wantGet) {
+		t.Errorf("GET responses = %v, want %v", got, wantGet)
+	}
+	// The overlay of DELETE declares 404 and 500, neither a default nor the handler replaces them.
+	wantDelete := map[string]string{
+		"200": "inferred",
+		"204": "Удален",
+		"404": "Заказ не найден",
+		"409": "inferred",
+		"500": "#/components/responses/NotFound",
+	}
+	if got := codes(item.Delete.Responses); !reflect.DeepEqual(got, wantDelete) {
+		t.Errorf("DELETE responses = %v, want %v", got, wantDelete)
+	}
+}
@@ -0,0 +1,845 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"one_c_swagger/internal/models"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	componentSchemaPrefix    = "#/components/schemas/"
+	componentParameterPrefix = "#/components/parameters/"
+	componentResponsePrefix  = "#/components/responses/"
+	componentBodyPrefix      = "#/components/requestBodies/"
+	componentHeaderPrefix    = "#/components/headers/"
+)
+
+var (
+	swaggerResponseCodeRe = regexp.MustCompile(`^[1-5]\d\d$`)
+	serverVariableRe      = regexp.MustCompile(`\{([^{}]+)\}`)
+)
+
+// unsupportedSwaggerMethods are the methods of a path item that Swagger 2.0 lacks.
+var unsupportedSwaggerMethods = []string{"MERGE", "TRACE", "CONNECT", "PROPFIND", "PROPPATCH", "MOVE", "COPY", "LOCK", "UNLOCK", "MKCOL"}
+
+// formMediaTypes are the request media types whose properties become formData parameters.
+var formMediaTypes = []string{"application/x-www-form-urlencoded", "multipart/form-data"}
+
+// ConvertToSwagger2 builds a Swagger 2.0 document from the generated OpenAPI document for
+// consumers that cannot read OpenAPI 3. The OpenAPI document is not modified.
+//
+// Servers become host, basePath and schemes, component schemas become definitions, security
+// schemes become security definitions and request bodies become body or formData parameters.
+// Constructs Swagger 2.0 cannot represent, such as callbacks, links, cookie parameters or
+// oneOf schemas, are dropped with a warning naming their location.
+func ConvertToSwagger2(openapi *models.OpenAPI, log *slog.Logger) *models.Swagger {
+	c := &swagger2Converter{openapi: openapi, log: log}
+	return c.convert()
+}
+
+type swagger2Converter struct {
+	openapi *models.OpenAPI
+	log     *slog.Logger
+	// securityDefinitions are the converted security schemes; requirements naming a scheme
+	// that could not be converted are dropped.
+	securityDefinitions map[string]models.SwaggerSecurityScheme
+	// parameters are the names of the converted component parameters.
+	parameters map[string]bool
+}
+
+// unsupported logs a construct that is dropped from the Swagger 2.0 document.
+func (c *swagger2Converter) unsupported(location, construct string) {
+	c.log.Warn("Construct cannot be represented in Swagger 2.0, skipping", "location", location, "construct", construct)
+}
+
+func (c *swagger2Converter) convert() *models.Swagger {
+	openapi := c.openapi
+	swagger := &models.Swagger{
+		Swagger:      "2.0",
+		Info:         c.convertInfo(openapi.Info),
+		Tags:         openapi.Tags,
+		Paths:        make(map[string]models.SwaggerPathItem),
+		ExternalDocs: openapi.ExternalDocs,
+		Extensions:   c.extensions(openapi.Extensions, "#"),
+	}
+	swagger.Host, swagger.BasePath, swagger.Schemes = c.convertServers(openapi.Servers, "#/servers")
+
+	c.securityDefinitions = make(map[string]models.SwaggerSecurityScheme)
+	for _, name := range sortedKeys(openapi.Components.SecuritySchemes) {
+		location := "#/components/securitySchemes/" + name
+		if definition, ok := c.convertSecurityScheme(openapi.Components.SecuritySchemes[name], location); ok {
+			c.securityDefinitions[name] = definition
+		}
+	}
+	if len(c.securityDefinitions) > 0 {
+		swagger.SecurityDefinitions = c.securityDefinitions
+	}
+	swagger.Security = c.convertSecurity(openapi.Security, "#/security")
+
+	if len(openapi.Components.Schemas) > 0 {
+		swagger.Definitions = make(map[string]*models.Schema)
+		for _, name := range sortedKeys(openapi.Components.Schemas) {
+			swagger.Definitions[name] = c.convertSchema(openapi.Components.Schemas[name], "#/components/schemas/"+name)
+		}
+	}
+
+	c.parameters = make(map[string]bool)
+	for _, name := range sortedKeys(openapi.Components.Parameters) {
+		location := "#/components/parameters/" + name
+		if parameter, ok := c.convertParameter(openapi.Components.Parameters[name], location); ok {
+			if swagger.Parameters == nil {
+				swagger.Parameters = make(map[string]models.SwaggerParameter)
+			}
+			swagger.Parameters[name] = parameter
+			c.parameters[name] = true
+		}
+	}
+
+	if len(openapi.Components.Responses) > 0 {
+		swagger.Responses = make(map[string]*models.SwaggerResponse)
+		for _, name := range sortedKeys(openapi.Components.Responses) {
+			response, _ := c.convertResponse(openapi.Components.Responses[name], "#/components/responses/"+name)
+			swagger.Responses[name] = response
+		}
+	}
+
+	// Request bodies and headers are inlined where they are used; links and callbacks have no
+	// counterpart at all.
+	if len(openapi.Components.Links) > 0 {
+		c.unsupported("#/components/links", "links")
+	}
+	if len(openapi.Components.Callbacks) > 0 {
+		c.unsupported("#/components/callbacks", "callbacks")
+	}
+	if len(openapi.Webhooks) > 0 {
+		c.unsupported("#/webhooks", "webhooks")
+	}
+
+	for _, path := range sortedKeys(openapi.Paths) {
+		swagger.Paths[path] = c.convertPathItem(path, openapi.Paths[path])
+	}
+	return swagger
+}
+
+func (c *swagger2Converter) convertInfo(info models.Info) models.Info {
+	if info.Summary != "" {
+		c.unsupported("#/info/summary", "info summary")
+		info.Summary = ""
+	}
+	info.Extensions = c.extensions(info.Extensions, "#/info")
+	if info.License != nil {
+		license := *info.License
+		license.Identifier = ""
+		info.License = &license
+	}
+	return info
+}
+
+// convertServers derives host, basePath and schemes from the first server. Further servers
+// only contribute their scheme, and only if they differ from the first in nothing else.
+func (c *swagger2Converter) convertServers(servers []models.Server, location string) (host, basePath string, schemes []string) {
+	if len(servers) == 0 {
+		return "", "", nil
+	}
+	first, err := parseServerURL(servers[0])
+	if err != nil {
+		c.unsupported(location, fmt.Sprintf("server %q: %v", servers[0].URL, err))
+		return "", "", nil
+	}
+	host = first.Host
+	basePath = strings.TrimSuffix(first.Path, "/")
+	if first.Scheme != "" {
+		schemes = append(schemes, first.Scheme)
+	}
+	for _, server := range servers[1:] {
+		u, err := parseServerURL(server)
+		if err != nil || u.Host != first.Host || strings.TrimSuffix(u.Path, "/") != basePath || u.Scheme == "" {
+			c.unsupported(location, fmt.Sprintf("server %q (only one host and base path are allowed)", server.URL))
+			continue
+		}
+		if !contains(schemes, u.Scheme) {
+			schemes = append(schemes, u.Scheme)
+		}
+	}
+	return host, basePath, schemes
+}
+
+// parseServerURL parses the server URL with its variables replaced by their defaults.
+func parseServerURL(server models.Server) (*url.URL, error) {
+	rawURL := serverVariableRe.ReplaceAllStringFunc(server.URL, func(match string) string {
+		if variable, ok := server.Variables[match[1:len(match)-1]]; ok {
+			return variable.Default
+		}
+		return match
+	})
+	return url.Parse(rawURL)
+}
+
+func (c *swagger2Converter) convertSecurityScheme(scheme models.SecurityScheme, location string) (models.SwaggerSecurityScheme, bool) {
+	definition := models.SwaggerSecurityScheme{
+		Type:        scheme.Type,
+		Description: scheme.Description,
+		Extensions:  c.extensions(scheme.Extensions, location),
+	}
+	switch scheme.Type {
+	case "http":
+		if strings.EqualFold(scheme.Scheme, "basic") {
+			definition.Type = "basic"
+			return definition, true
+		}
+		c.unsupported(location, fmt.Sprintf("HTTP authentication scheme %q", scheme.Scheme))
+		return definition, false
+	case "apiKey":
+		if scheme.In == "cookie" {
+			c.unsupported(location, "API key in a cookie")
+			return definition, false
+		}
+		definition.Name = scheme.Name
+		definition.In = scheme.In
+		return definition, true
+	case "oauth2":
+		if scheme.Flows == nil {
+			c.unsupported(location, "OAuth2 scheme without flows")
+			return definition, false
+		}
+		flows := []struct {
+			name string
+			flow *models.OAuthFlow
+		}{
+			{"accessCode", scheme.Flows.AuthorizationCode},
+			{"implicit", scheme.Flows.Implicit},
+			{"password", scheme.Flows.Password},
+			{"application", scheme.Flows.ClientCredentials},
+		}
+		for _, f := range flows {
+			if f.flow == nil {
+				continue
+			}
+			if definition.Flow != "" {
+				// Swagger 2.0 allows a single flow per security definition.
+				c.unsupported(location, fmt.Sprintf("OAuth2 %s flow (only the %s flow is kept)", f.name, definition.Flow))
+				continue
+			}
+			definition.Flow = f.name
+			definition.AuthorizationURL = f.flow.AuthorizationURL
+			definition.TokenURL = f.flow.TokenURL
+			definition.Scopes = f.flow.Scopes
+			if definition.Scopes == nil {
+				definition.Scopes = map[string]string{}
+			}
+		}
+		if definition.Flow == "" {
+			c.unsupported(location, "OAuth2 scheme without flows")
+			return definition, false
+		}
+		return definition, true
+	default:
+		c.unsupported(location, fmt.Sprintf("security scheme type %q", scheme.Type))
+		return definition, false
+	}
+}
+
+// convertSecurity drops the requirements that name a security scheme which was not converted.
+func (c *swagger2Converter) convertSecurity(security []models.SecurityRequirement, location string) []models.SecurityRequirement {
+	if security == nil {
+		return nil
+	}
+	converted := make([]models.SecurityRequirement, 0, len(security))
+	for _, requirement := range security {
+		supported := true
+		for name := range requirement {
+			if _, ok := c.securityDefinitions[name]; !ok {
+				supported = false
+			}
+		}
+		if supported {
+			converted = append(converted, requirement)
+		}
+	}
+	if len(converted) < len(security) {
+		c.unsupported(location, "security requirements with unsupported schemes")
+		if len(converted) == 0 {
+			return nil
+		}
+	}
+	return converted
+}
+
+func (c *swagger2Converter) convertPathItem(path string, item models.PathItem) models.SwaggerPathItem {
+	converted := models.SwaggerPathItem{
+		XAnyMethod: item.XAnyMethod,
+		XWildcard:  item.XWildcard,
+		Extensions: c.extensions(item.Extensions, path),
+	}
+	if item.Summary != "" || item.Description != "" {
+		c.unsupported(path, "path item summary and description")
+	}
+	if len(item.Servers) > 0 {
+		c.unsupported(path, "path item servers")
+	}
+	for i, parameter := range item.Parameters {
+		location := fmt.Sprintf("%s parameters[%d]", path, i)
+		if p, ok := c.convertParameter(parameter, location); ok {
+			converted.Parameters = append(converted.Parameters, p)
+		}
+	}
+
+	operations := []struct {
+		method string
+		source *models.Operation
+		target **models.SwaggerOperation
+	}{
+		{"get", item.Get, &converted.Get},
+		{"put", item.Put, &converted.Put},
+		{"post", item.Post, &converted.Post},
+		{"delete", item.Delete, &converted.Delete},
+		{"options", item.Options, &converted.Options},
+		{"head", item.Head, &converted.Head},
+		{"patch", item.Patch, &converted.Patch},
+	}
+	for _, op := range operations {
+		if op.source != nil {
+			*op.target = c.convertOperation(op.source, strings.ToUpper(op.method)+" "+path)
+		}
+	}
+
+	// The remaining methods are not part of Swagger 2.0, which has no way to extend the set.
+	for _, method := range unsupportedSwaggerMethods {
+		if pathItemOperation(&item, method) != nil {
+			c.unsupported(method+" "+path, method+" method")
+		}
+	}
+	return converted
+}
+
+func (c *swagger2Converter) convertOperation(op *models.Operation, location string) *models.SwaggerOperation {
+	converted := &models.SwaggerOperation{
+		Tags:         op.Tags,
+		Summary:      op.Summary,
+		Description:  op.Description,
+		ExternalDocs: op.ExternalDocs,
+		OperationID:  op.OperationID,
+		Responses:    make(map[string]*models.SwaggerResponse),
+		Deprecated:   op.Deprecated,
+		Security:     c.convertSecurity(op.Security, location+" security"),
+		XName:        op.XName,
+		Extensions:   c.extensions(op.Extensions, location),
+	}
+
+	for _, parameter := range op.Parameters {
+		if p, ok := c.convertParameter(parameter, location+" parameter "+parameter.Name); ok {
+			converted.Parameters = append(converted.Parameters, p)
+		}
+	}
+	if op.RequestBody != nil {
+		parameters, consumes := c.convertRequestBody(*op.RequestBody, location+" request body")
+		converted.Parameters = append(converted.Parameters, parameters...)
+		converted.Consumes = consumes
+	}
+
+	for _, code := range sortedKeys(op.Responses) {
+		if code != "default" && !swaggerResponseCodeRe.MatchString(code) {
+			c.unsupported(location, fmt.Sprintf("response code %q", code))
+			continue
+		}
+		response, produces := c.convertResponse(op.Responses[code], location+" response "+code)
+		converted.Responses[code] = response
+		for _, mediaType := range produces {
+			if !contains(converted.Produces, mediaType) {
+				converted.Produces = append(converted.Produces, mediaType)
+			}
+		}
+	}
+
+	if len(op.Callbacks) > 0 {
+		c.unsupported(location, "callbacks")
+	}
+	if len(op.Servers) > 0 {
+		c.unsupported(location, "operation servers")
+	}
+	return converted
+}
+
+func (c *swagger2Converter) convertParameter(parameter models.Parameter, location string) (models.SwaggerParameter, bool) {
+	if parameter.Ref != "" {
+		name, ok := strings.CutPrefix(parameter.Ref, componentParameterPrefix)
+		if !ok || (c.parameters != nil && !c.parameters[name]) {
+			c.unsupported(location, fmt.Sprintf("parameter reference %q", parameter.Ref))
+			return models.SwaggerParameter{}, false
+		}
+		return models.SwaggerParameter{Ref: "#/parameters/" + name}, true
+	}
+	if parameter.In == "cookie" {
+		c.unsupported(location, "cookie parameter")
+		return models.SwaggerParameter{}, false
+	}
+	if parameter.Schema == nil {
+		c.unsupported(location, "parameter without a schema")
+		return models.SwaggerParameter{}, false
+	}
+
+	converted := models.SwaggerParameter{
+		Name:            parameter.Name,
+		In:              parameter.In,
+		Description:     parameter.Description,
+		Required:        parameter.Required,
+		AllowEmptyValue: parameter.AllowEmptyValue,
+		SwaggerItems:    c.convertItems(parameter.Schema, location),
+		XWildcard:       parameter.XWildcard,
+		XName:           parameter.XName,
+		XInferred:       parameter.XInferred,
+		Extensions:      c.extensions(parameter.Extensions, location),
+	}
+	if converted.Type == "array" {
+		converted.CollectionFormat = collectionFormat(parameter)
+	}
+	return converted, true
+}
+
+// collectionFormat maps the serialisation style of an array parameter to the Swagger 2.0
+// collection format; csv, the default, is omitted.
+func collectionFormat(parameter models.Parameter) string {
+	switch parameter.Style {
+	case "spaceDelimited":
+		return "ssv"
+	case "pipeDelimited":
+		return "pipes"
+	case "", "form":
+		if parameter.In != "query" {
+			return ""
+		}
+		if parameter.Explode == nil || *parameter.Explode {
+			return "multi"
+		}
+	}
+	return ""
+}
+
+// convertItems describes the value of a parameter or header, which must be a primitive or an
+// array of primitives in Swagger 2.0.
+func (c *swagger2Converter) convertItems(schema *models.Schema, location string) models.SwaggerItems {
+	if name, ok := strings.CutPrefix(schema.Ref, componentSchemaPrefix); ok {
+		if resolved, ok := c.openapi.Components.Schemas[name]; ok {
+			schema = resolved
+		}
+	}
+	items := models.SwaggerItems{
+		Type:        schemaType(schema.Type),
+		Format:      schema.Format,
+		Default:     schema.Default,
+		MaxLength:   schema.MaxLength,
+		MinLength:   schema.MinLength,
+		Pattern:     schema.Pattern,
+		MaxItems:    schema.MaxItems,
+		MinItems:    schema.MinItems,
+		UniqueItems: schema.UniqueItems,
+		Enum:        schema.Enum,
+		MultipleOf:  schema.MultipleOf,
+	}
+	items.Maximum, items.ExclusiveMaximum = exclusiveBoundTo20(schema.ExclusiveMaximum, schema.Maximum)
+	items.Minimum, items.ExclusiveMinimum = exclusiveBoundTo20(schema.ExclusiveMinimum, schema.Minimum)
+
+	switch items.Type {
+	case "array":
+		if schema.Items == nil {
+			items.Items = &models.SwaggerItems{Type: "string"}
+		} else {
+			converted := c.convertItems(schema.Items, location)
+			items.Items = &converted
+		}
+	case "string", "number", "integer", "boolean":
+	case "":
+		// A schema without a type accepts any value, which arrives as a string.
+		items.Type = "string"
+	default:
+		c.unsupported(location, "non-primitive parameter or header schema (described as a string)")
+		items = models.SwaggerItems{Type: "string"}
+	}
+	return items
+}
+
+func (c *swagger2Converter) convertRequestBody(body models.RequestBody, location string) ([]models.SwaggerParameter, []string) {
+	if body.Ref != "" {
+		name, _ := strings.CutPrefix(body.Ref, componentBodyPrefix)
+		resolved, ok := c.openapi.Components.RequestBodies[name]
+		if !ok {
+			c.unsupported(location, fmt.Sprintf("request body reference %q", body.Ref))
+			return nil, nil
+		}
+		body = resolved
+	}
+
+	var consumes, formTypes []string
+	for _, mediaType := range sortedKeys(body.Content) {
+		consumes = append(consumes, mediaType)
+		if contains(formMediaTypes, mediaType) {
+			formTypes = append(formTypes, mediaType)
+		}
+	}
+
+	if len(formTypes) > 0 && len(formTypes) == len(consumes) {
+		return c.convertFormData(body, formTypes, location), consumes
+	}
+	if len(formTypes) > 0 {
+		// The body and formData parameters are mutually exclusive.
+		c.unsupported(location, "form media types next to other media types")
+		consumes = removeAll(consumes, formTypes)
+	}
+
+	mediaType := preferredMediaType(body.Content, consumes)
+	parameter := models.SwaggerParameter{
+		Name:        "body",
+		In:          "body",
+		Description: body.Description,
+		Required:    body.Required,
+		Schema:      &models.Schema{},
+		XInferred:   body.XInferred,
+		Extensions:  c.extensions(body.Extensions, location),
+	}
+	if schema := body.Content[mediaType].Schema; schema != nil {
+		parameter.Schema = c.convertSchema(schema, location)
+	}
+	c.checkMediaTypeSchemas(body.Content, consumes, mediaType, location)
+	return []models.SwaggerParameter{parameter}, consumes
+}
+
+// convertFormData turns the properties of a form schema into formData parameters.
+func (c *swagger2Converter) convertFormData(body models.RequestBody, formTypes []string, location string) []models.SwaggerParameter {
+	mediaType := formTypes[len(formTypes)-1]
+	c.checkMediaTypeSchemas(body.Content, formTypes, mediaType, location)
+	schema := body.Content[mediaType].Schema
+	if schema == nil {
+		return nil
+	}
+	if name, ok := strings.CutPrefix(schema.Ref, componentSchemaPrefix); ok {
+		if resolved, ok := c.openapi.Components.Schemas[name]; ok {
+			schema = resolved
+		}
+	}
+	if len(schema.Properties) == 0 {
+		c.unsupported(location, "form without properties")
+		return nil
+	}
+
+	var parameters []models.SwaggerParameter
+	for _, name := range sortedKeys(schema.Properties) {
+		property := schema.Properties[name]
+		parameter := models.SwaggerParameter{
+			Name:        name,
+			In:          "formData",
+			Description: property.Description,
+			Required:    contains(schema.Required, name),
+		}
+		if property.Format == "binary" && mediaType == "multipart/form-data" {
+			parameter.Type = "file"
+		} else {
+			parameter.SwaggerItems = c.convertItems(property, location+" property "+name)
+		}
+		parameters = append(parameters, parameter)
+	}
+	return parameters
+}
+
+func (c *swagger2Converter) convertResponse(response *models.Response, location string) (*models.SwaggerResponse, []string) {
+	if response.Ref != "" {
+		name, ok := strings.CutPrefix(response.Ref, componentResponsePrefix)
+		if !ok {
+			c.unsupported(location, fmt.Sprintf("response reference %q", response.Ref))
+			return &models.SwaggerResponse{Description: ""}, nil
+		}
+		var produces []string
+		if resolved, ok := c.openapi.Components.Responses[name]; ok {
+			produces = sortedKeys(resolved.Content)
+		}
+		return &models.SwaggerResponse{Ref: "#/responses/" + name}, produces
+	}
+
+	converted := &models.SwaggerResponse{
+		Description: response.Description,
+		XInferred:   response.XInferred,
+		Extensions:  c.extensions(response.Extensions, location),
+	}
+	produces := sortedKeys(response.Content)
+	if len(produces) > 0 {
+		mediaType := preferredMediaType(response.Content, produces)
+		if schema := response.Content[mediaType].Schema; schema != nil {
+			converted.Schema = c.convertSchema(schema, location)
+		}
+		c.checkMediaTypeSchemas(response.Content, produces, mediaType, location)
+		for _, mediaType := range produces {
+			if example := mediaTypeExample(response.Content[mediaType]); example != nil {
+				if converted.Examples == nil {
+					converted.Examples = make(map[string]interface{})
+				}
+				converted.Examples[mediaType] = example
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(response.Headers) {
+		header := response.Headers[name]
+		headerLocation := location + " header " + name
+		if header.Ref != "" {
+			resolved, ok := c.openapi.Components.Headers[strings.TrimPrefix(header.Ref, componentHeaderPrefix)]
+			if !ok {
+				c.unsupported(headerLocation, fmt.Sprintf("header reference %q", header.Ref))
+				continue
+			}
+			header = resolved
+		}
+		if header.Schema == nil {
+			c.unsupported(headerLocation, "header without a schema")
+			continue
+		}
+		if converted.Headers == nil {
+			converted.Headers = make(map[string]models.SwaggerHeader)
+		}
+		converted.Headers[name] = models.SwaggerHeader{
+			Description:  header.Description,
+			SwaggerItems: c.convertItems(header.Schema, headerLocation),
+			Extensions:   c.extensions(header.Extensions, headerLocation),
+		}
+	}
+
+	if len(response.Links) > 0 {
+		c.unsupported(location, "links")
+	}
+	return converted, produces
+}
+
+// checkMediaTypeSchemas warns when the media types of a body differ in their schemas: Swagger
+// 2.0 has a single schema per body, taken from the chosen media type.
+func (c *swagger2Converter) checkMediaTypeSchemas(content map[string]models.MediaType, mediaTypes []string, chosen, location string) {
+	for _, mediaType := range mediaTypes {
+		if mediaType != chosen && !sameSchema(content[mediaType].Schema, content[chosen].Schema) {
+			c.unsupported(location, fmt.Sprintf("schema of %s (the schema of %s is used)", mediaType, chosen))
+		}
+	}
+}
+
+// convertSchema copies the schema, keeping what Swagger 2.0 supports and pointing references
+// to definitions. OpenAPI 3.1 schemas are handled as well: a "null" type or a null
+// alternative becomes x-nullable.
+func (c *swagger2Converter) convertSchema(schema *models.Schema, location string) *models.Schema {
+	if schema == nil {
+		return nil
+	}
+	converted := &models.Schema{
+		Ref:           schema.Ref,
+		Default:       schema.Default,
+		Description:   schema.Description,
+		Enum:          schema.Enum,
+		Example:       schema.Example,
+		ExternalDocs:  schema.ExternalDocs,
+		Format:        schema.Format,
+		MaxItems:      schema.MaxItems,
+		MaxLength:     schema.MaxLength,
+		MaxProperties: schema.MaxProperties,
+		MinItems:      schema.MinItems,
+		MinLength:     schema.MinLength,
+		MinProperties: schema.MinProperties,
+		MultipleOf:    schema.MultipleOf,
+		Pattern:       schema.Pattern,
+		ReadOnly:      schema.ReadOnly,
+		Required:      schema.Required,
+		Title:         schema.Title,
+		UniqueItems:   schema.UniqueItems,
+		XML:           schema.XML,
+		Extensions:    c.extensions(schema.Extensions, location),
+	}
+	if name, ok := strings.CutPrefix(schema.Ref, componentSchemaPrefix); ok {
+		converted.Ref = "#/definitions/" + name
+	}
+	nullable := schema.Nullable
+
+	var types []string
+	for _, typ := range schema.Type {
+		if typ == "null" {
+			nullable = true
+		} else {
+			types = append(types, typ)
+		}
+	}
+	if len(types) > 1 {
+		c.unsupported(location, fmt.Sprintf("multiple schema types %v (%s is used)", types, types[0]))
+	}
+	if len(types) > 0 {
+		converted.Type = models.Types{types[0]}
+	}
+	if converted.Example == nil && len(schema.Examples) > 0 {
+		converted.Example = schema.Examples[0]
+	}
+	var exclusiveMaximum, exclusiveMinimum bool
+	converted.Maximum, exclusiveMaximum = exclusiveBoundTo20(schema.ExclusiveMaximum, schema.Maximum)
+	converted.Minimum, exclusiveMinimum = exclusiveBoundTo20(schema.ExclusiveMinimum, schema.Minimum)
+	if exclusiveMaximum {
+		converted.ExclusiveMaximum = &models.ExclusiveBound{Exclusive: true}
+	}
+	if exclusiveMinimum {
+		converted.ExclusiveMinimum = &models.ExclusiveBound{Exclusive: true}
+	}
+
+	converted.Items = c.convertSchema(schema.Items, location)
+	if schema.Properties != nil {
+		converted.Properties = make(map[string]*models.Schema, len(schema.Properties))
+		for _, name := range sortedKeys(schema.Properties) {
+			converted.Properties[name] = c.convertSchema(schema.Properties[name], location)
+		}
+	}
+	if schema.AdditionalProperties != nil {
+		converted.AdditionalProperties = &models.AdditionalProperties{
+			Allowed: schema.AdditionalProperties.Allowed,
+			Schema:  c.convertSchema(schema.AdditionalProperties.Schema, location),
+		}
+	}
+	for _, member := range schema.AllOf {
+		converted.AllOf = append(converted.AllOf, c.convertSchema(member, location))
+	}
+
+	// An alternative between a schema and null, as written by OpenAPI 3.1, is the nullable
+	// schema itself; other alternatives cannot be represented.
+	for _, alternatives := range [][]*models.Schema{schema.OneOf, schema.AnyOf} {
+		var members []*models.Schema
+		for _, member := range alternatives {
+			if len(member.Type) == 1 && member.Type[0] == "null" {
+				nullable = true
+			} else {
+				members = append(members, member)
+			}
+		}
+		switch {
+		case len(members) == 1:
+			converted.AllOf = append(converted.AllOf, c.convertSchema(members[0], location))
+		case len(members) > 1:
+			c.unsupported(location, "oneOf and anyOf schemas")
+		}
+	}
+
+	if schema.Not != nil {
+		c.unsupported(location, "not schema")
+	}
+	if schema.Discriminator != nil {
+		c.unsupported(location, "discriminator")
+	}
+	if schema.WriteOnly {
+		c.unsupported(location, "writeOnly")
+	}
+	if schema.Deprecated {
+		c.unsupported(location, "deprecated schema")
+	}
+	if nullable {
+		if converted.Extensions == nil {
+			converted.Extensions = make(map[string]interface{})
+		}
+		converted.Extensions["x-nullable"] = true
+	}
+	return converted
+}
+
+// exclusiveBoundTo20 returns the bound and whether it is exclusive, for both the OpenAPI 3.0
+// flag and the OpenAPI 3.1 bound.
+func exclusiveBoundTo20(exclusive *models.ExclusiveBound, bound *float64) (*float64, bool) {
+	if exclusive == nil {
+		return bound, false
+	}
+	if exclusive.Value != nil {
+		return exclusive.Value, true
+	}
+	return bound, exclusive.Exclusive && bound != nil
+}
+
+// schemaType returns the single type of a schema; a "null" type of OpenAPI 3.1 is skipped.
+func schemaType(types models.Types) string {
+	for _, typ := range types {
+		if typ != "null" {
+			return typ
+		}
+	}
+	return ""
+}
+
+// preferredMediaType picks the media type whose schema describes a body: JSON if present,
+// otherwise the first media type.
+func preferredMediaType(content map[string]models.MediaType, mediaTypes []string) string {
+	for _, mediaType := range mediaTypes {
+		if mediaType == "application/json" {
+			return mediaType
+		}
+	}
+	if len(mediaTypes) == 0 {
+		return ""
+	}
+	return mediaTypes[0]
+}
+
+// mediaTypeExample returns the example of the media type, or the value of its first example.
+func mediaTypeExample(mediaType models.MediaType) interface{} {
+	if mediaType.Example != nil {
+		return mediaType.Example
+	}
+	for _, name := range sortedKeys(mediaType.Examples) {
+		if value := mediaType.Examples[name].Value; value != nil {
+			return value
+		}
+	}
+	return nil
+}
+
+func sameSchema(a, b *models.Schema) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	dataA, errA := json.Marshal(a)
+	dataB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(dataA) == string(dataB)
+}
+
+// extensions copies the x-* fields of an object. Its other undeclared fields, kept from the
+// overlays, are OpenAPI 3 or JSON Schema keywords that Swagger 2.0 does not have.
+func (c *swagger2Converter) extensions(extensions map[string]interface{}, location string) map[string]interface{} {
+	var copied map[string]interface{}
+	for _, key := range sortedKeys(extensions) {
+		if !strings.HasPrefix(key, "x-") {
+			c.unsupported(location, fmt.Sprintf("field %q", key))
+			continue
+		}
+		if copied == nil {
+			copied = make(map[string]interface{})
+		}
+		copied[key] = extensions[key]
+	}
+	return copied
+}
+
+func contains(values []string, value string) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+func removeAll(values, remove []string) []string {
+	var kept []string
+	for _, value := range values {
+		if !contains(remove, value) {
+			kept = append(kept, value)
+		}
+	}
+	return kept
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,163 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"regexp"
+	"strings"
+	"testing"
+
+	"one_c_swagger/internal/models"
+)
+
+func TestConvertToSwagger2(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+		want string
+		// warnings lists the locations of the constructs that are dropped.
+		warnings []string
+	}{
+		{
+			name: "document",
+			spec: `{"openapi": "3.0.3", "info": {"title": "API", "version": "1", "license": {"name": "MIT", "url": "https://mit.example"}},
+				"servers": [{"url": "https://{host}/hs/billing/", "variables": {"host": {"default": "example.com"}}}, {"url": "http://example.com/hs/billing"}, {"url": "https://other.example/hs"}],
+				"paths": {},
+				"security": [{"basic": []}, {"bearer": []}],
+				"components": {
+					"schemas": {"Order": {"type": "object", "properties": {"line": {"$ref": "#/components/schemas/Line"}}}, "Line": {"type": "string"}},
+					"securitySchemes": {
+						"basic": {"type": "http", "scheme": "basic"},
+						"bearer": {"type": "http", "scheme": "bearer"},
+						"key": {"type": "apiKey", "name": "X-Key", "in": "header"},
+						"oauth": {"type": "oauth2", "flows": {"clientCredentials": {"tokenUrl": "https://example.com/token", "scopes": {}}, "password": {"tokenUrl": "https://example.com/token", "scopes": {}}}}}}}`,
+			want: `{"swagger": "2.0", "info": {"title": "API", "version": "1", "license": {"name": "MIT", "url": "https://mit.example"}},
+				"host": "example.com", "basePath": "/hs/billing", "schemes": ["https", "http"],
+				"paths": {},
+				"definitions": {"Order": {"type": "object", "properties": {"line": {"$ref": "#/definitions/Line"}}}, "Line": {"type": "string"}},
+				"securityDefinitions": {
+					"basic": {"type": "basic"},
+					"key": {"type": "apiKey", "name": "X-Key", "in": "header"},
+					"oauth": {"type": "oauth2", "flow": "password", "tokenUrl": "https://example.com/token", "scopes": {}}},
+				"security": [{"basic": []}]}`,
+			warnings: []string{"#/servers", "#/components/securitySchemes/bearer", "#/components/securitySchemes/oauth", "#/security"},
+		},
+		{
+			name: "operations",
+			spec: `{"openapi": "3.0.3", "info": {"title": "API", "version": "1"},
+				"paths": {"/orders/{id}": {
+					"parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "string", "format": "uuid"}}],
+					"get": {"operationId": "getOrder",
+						"parameters": [
+							{"name": "tags", "in": "query", "schema": {"type": "array", "items": {"type": "string"}}},
+							{"name": "session", "in": "cookie", "schema": {"type": "string"}}],
+						"responses": {
+							"200": {"description": "OK", "headers": {"X-Total": {"schema": {"type": "integer"}}},
+								"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Order"}, "example": {"id": "1"}}, "application/xml": {"schema": {"$ref": "#/components/schemas/Order"}}}},
+							"4XX": {"description": "Client error"},
+							"default": {"$ref": "#/components/responses/Error"}}},
+					"propfind": {"responses": {"207": {"description": "Multi-Status"}}}}},
+				"components": {
+					"schemas": {"Order": {"type": "object"}},
+					"responses": {"Error": {"description": "Error", "content": {"text/plain": {"schema": {"type": "string"}}}}}}}`,
+			want: `{"swagger": "2.0", "info": {"title": "API", "version": "1"},
+				"paths": {"/orders/{id}": {
+					"parameters": [{"name": "id", "in": "path", "required": true, "type": "string", "format": "uuid"}],
+					"get": {"operationId": "getOrder",
+						"produces": ["application/json", "application/xml", "text/plain"],
+						"parameters": [{"name": "tags", "in": "query", "type": "array", "items": {"type": "string"}, "collectionFormat": "multi"}],
+						"responses": {
+							"200": {"description": "OK", "schema": {"$ref": "#/definitions/Order"},
+								"headers": {"X-Total": {"type": "integer"}},
+								"examples": {"application/json": {"id": "1"}}},
+							"default": {"$ref": "#/responses/Error"}}}}},
+				"definitions": {"Order": {"type": "object"}},
+				"responses": {"Error": {"description": "Error", "schema": {"type": "string"}}}}`,
+			warnings: []string{"GET /orders/{id} parameter session", "GET /orders/{id}", "PROPFIND /orders/{id}"},
+		},
+		{
+			name: "request bodies",
+			spec: `{"openapi": "3.0.3", "info": {"title": "API", "version": "1"},
+				"paths": {"/files": {
+					"post": {"requestBody": {"required": true, "content": {"multipart/form-data": {"schema": {"type": "object", "required": ["file"],
+						"properties": {"file": {"type": "string", "format": "binary"}, "note": {"type": "string"}}}}}},
+						"responses": {"201": {"description": "Created"}}},
+					"put": {"requestBody": {"$ref": "#/components/requestBodies/File"}, "responses": {"204": {"description": "No content"}}}}},
+				"components": {"requestBodies": {"File": {"description": "File", "content": {"application/octet-stream": {"schema": {"type": "string", "format": "binary"}}}}}}}`,
+			want: `{"swagger": "2.0", "info": {"title": "API", "version": "1"},
+				"paths": {"/files": {
+					"post": {"consumes": ["multipart/form-data"],
+						"parameters": [
+							{"name": "file", "in": "formData", "required": true, "type": "file"},
+							{"name": "note", "in": "formData", "type": "string"}],
+						"responses": {"201": {"description": "Created"}}},
+					"put": {"consumes": ["application/octet-stream"],
+						"parameters": [{"name": "body", "in": "body", "description": "File", "schema": {"type": "string", "format": "binary"}}],
+						"responses": {"204": {"description": "No content"}}}}}}`,
+		},
+		{
+			name: "schemas",
+			spec: `{"openapi": "3.1.0", "info": {"title": "API", "version": "1", "summary": "Summary"},
+				"paths": {},
+				"components": {"schemas": {
+					"Nullable": {"type": ["string", "null"], "examples": ["a"]},
+					"Alternative": {"anyOf": [{"$ref": "#/components/schemas/Nullable"}, {"type": "null"}]},
+					"Union": {"oneOf": [{"type": "string"}, {"type": "integer"}], "const": 1},
+					"Bound": {"type": "number", "exclusiveMaximum": 10, "x-1c-name": "Граница"}}}}`,
+			want: `{"swagger": "2.0", "info": {"title": "API", "version": "1"},
+				"paths": {},
+				"definitions": {
+					"Nullable": {"type": "string", "example": "a", "x-nullable": true},
+					"Alternative": {"allOf": [{"$ref": "#/definitions/Nullable"}], "x-nullable": true},
+					"Union": {},
+					"Bound": {"type": "number", "maximum": 10, "exclusiveMaximum": true, "x-1c-name": "Граница"}}}`,
+			warnings: []string{"#/info/summary", "#/components/schemas/Union", "#/components/schemas/Union"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			openapi := parseOpenAPI(t, tt.spec)
+			var logs bytes.Buffer
+			swagger := ConvertToSwagger2(openapi, slog.New(slog.NewTextHandler(&logs, nil)))
+			assertJSON(t, swagger, tt.want)
+
+			var warnings []string
+			for _, match := range warningLocationRe.FindAllStringSubmatch(logs.String(), -1) {
+				warnings = append(warnings, match[1])
+			}
+			if strings.Join(warnings, "\n") != strings.Join(tt.warnings, "\n") {
+				t.Errorf("dropped constructs at %q, want %q\n%s", warnings, tt.warnings, logs.String())
+			}
+		})
+	}
+}
+
+// warningLocationRe extracts the location of a dropped construct from the text log.
+var warningLocationRe = regexp.MustCompile(`level=WARN msg="Construct cannot be represented in Swagger 2.0, skipping" location="?([^"\n]*?)"? construct`)
+
+// TestSwaggerOAuth2ExtensionCollision checks that extensions named like the fields of an
+// OAuth2 definition do not write the fields a second time.
+func TestSwaggerOAuth2ExtensionCollision(t *testing.T) {
+	scheme := models.SwaggerSecurityScheme{
+		Type:     "oauth2",
+		Flow:     "application",
+		TokenURL: "https://example.com/token",
+		Extensions: map[string]interface{}{
+			"type":             "basic",
+			"flow":             "implicit",
+			"authorizationUrl": "https://other.example/authorize",
+			"tokenUrl":         "https://other.example/token",
+			"scopes":           map[string]string{"read": "Read"},
+			"x-client":         "1c",
+		},
+	}
+	data, err := json.Marshal(scheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":"oauth2","flow":"application","tokenUrl":"https://example.com/token","scopes":{},"x-client":"1c"}`
+	if string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+}
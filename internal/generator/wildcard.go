@@ -0,0 +1,58 @@
+package generator
+
+import (
+	"fmt"
+	"one_c_swagger/internal/models"
+	"strings"
+)
+
+// translateWildcard rewrites the catch-all `*` segment of a 1C URL template according to the
+// configured strategy. It returns the rewritten template, the name of the path parameter that
+// replaces the wildcard (empty with the strip strategy) and whether a wildcard was found. The
+// parameter gets a numeric suffix when the template already has a placeholder with its name.
+func translateWildcard(template string, opts Options) (string, string, bool) {
+	segments := strings.Split(template, "/")
+	for i, segment := range segments {
+		if segment != "*" {
+			continue
+		}
+		head := strings.Join(segments[:i], "/")
+		if opts.wildcardStrategy() == WildcardStrip {
+			return head, "", true
+		}
+		name := uniqueParameterName(opts.wildcardParamName(), pathParameterNames(head))
+		return head + "/{" + name + "}", name, true
+	}
+	return template, "", false
+}
+
+// uniqueParameterName returns name, or name with the first numeric suffix that is not taken.
+func uniqueParameterName(name string, taken []string) string {
+	unique := name
+	for i := 2; contains(taken, unique); i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	return unique
+}
+
+// markWildcardParameter flags the path parameter that replaces a 1C wildcard tail.
+func markWildcardParameter(params []models.Parameter, name string) {
+	for i := range params {
+		if params[i].In != "path" || (params[i].Name != name && params[i].XName != name) {
+			continue
+		}
+		params[i].XWildcard = true
+		if params[i].Description == "" {
+			params[i].Description = "Remainder of the URL matched by the 1C wildcard `*`; may contain `/`."
+		}
+	}
+}
+
+// wildcardDescription documents a stripped wildcard tail in the operation description.
+func wildcardDescription(description, path string) string {
+	note := fmt.Sprintf("The path accepts an arbitrary tail after `%s` (1C wildcard `*`).", path)
+	if description == "" {
+		return note
+	}
+	return description + "\n\n" + note
+}
@@ -0,0 +1,147 @@
+package generator
+
+import (
+	"bytes"
+	"log/slog"
+	"sort"
+	"strings"
+	"testing"
+
+	"one_c_swagger/internal/reader"
+)
+
+// testService builds an HTTP service from "template METHOD" pairs; each URL template is named
+// after its position.
+func testService(name, rootURL string, templates ...string) reader.HTTPService {
+	var service reader.HTTPService
+	service.Properties.Name = name
+	service.Properties.RootURL = rootURL
+	for i, entry := range templates {
+		template, method, _ := strings.Cut(entry, " ")
+		var urlTemplate reader.URLTemplate
+		urlTemplate.Properties.Name = "Шаблон" + string(rune('A'+i))
+		urlTemplate.Properties.Template = template
+		var m reader.Method
+		m.Properties.Name = method
+		m.Properties.HTTPMethod = method
+		urlTemplate.Methods = append(urlTemplate.Methods, m)
+		service.URLTemplates = append(service.URLTemplates, urlTemplate)
+	}
+	return service
+}
+
+func TestTranslateWildcard(t *testing.T) {
+	tests := []struct {
+		template  string
+		strategy  string
+		paramName string
+		want      string
+		wantParam string
+		wildcard  bool
+	}{
+		{template: "/version", want: "/version"},
+		{template: "/bill/{id}/*", want: "/bill/{id}/{tail}", wantParam: "tail", wildcard: true},
+		{template: "/files/*", paramName: "path", want: "/files/{path}", wantParam: "path", wildcard: true},
+		{template: "/{tail}/*", want: "/{tail}/{tail2}", wantParam: "tail2", wildcard: true},
+		{template: "/{tail}/{tail2}/*", want: "/{tail}/{tail2}/{tail3}", wantParam: "tail3", wildcard: true},
+		{template: "/bill/{id}/*", strategy: WildcardStrip, want: "/bill/{id}", wildcard: true},
+		{template: "/*", strategy: WildcardStrip, want: "", wildcard: true},
+	}
+	for _, tt := range tests {
+		opts := Options{WildcardStrategy: tt.strategy, WildcardParamName: tt.paramName}
+		got, param, wildcard := translateWildcard(tt.template, opts)
+		if got != tt.want || param != tt.wantParam || wildcard != tt.wildcard {
+			t.Errorf("translateWildcard(%q, %q) = %q, %q, %t; want %q, %q, %t", tt.template, tt.strategy, got, param, wildcard, tt.want, tt.wantParam, tt.wildcard)
+		}
+	}
+}
+
+func TestGenerateWildcardPaths(t *testing.T) {
+	tests := []struct {
+		name      string
+		templates []string
+		opts      Options
+		// want maps the paths to their operation ids.
+		want      map[string]string
+		wantWarn  string
+		wantError bool
+	}{
+		{
+			name:      "param",
+			templates: []string{"/Price GET", "/Price/* GET"},
+			want:      map[string]string{"/s/Price": "sШаблонAGET", "/s/Price/{tail}": "sШаблонBGET"},
+		},
+		{
+			name:      "strip",
+			templates: []string{"/Price/* GET"},
+			opts:      Options{WildcardStrategy: WildcardStrip},
+			want:      map[string]string{"/s/Price": "sШаблонAGET"},
+		},
+		{
+			name:      "strip collision keeps the first operation",
+			templates: []string{"/Price GET", "/Price/* GET", "/Price/* POST"},
+			opts:      Options{WildcardStrategy: WildcardStrip},
+			want:      map[string]string{"/s/Price": "sШаблонAGET sШаблонCPOST"},
+			wantWarn:  "Duplicate operation skipped",
+		},
+		{
+			name:      "strip collision in strict mode",
+			templates: []string{"/Price GET", "/Price/* GET"},
+			opts:      Options{WildcardStrategy: WildcardStrip, Strict: true},
+			wantError: true,
+		},
+		{
+			name:      "param collision",
+			templates: []string{"/{tail}/* GET"},
+			want:      map[string]string{"/s/{tail}/{tail2}": "sШаблонAGET"},
+			wantWarn:  "Wildcard parameter renamed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			services := []reader.HTTPService{testService("s", "s", tt.templates...)}
+			openapi, err := GenerateOpenAPI(services, nil, nil, nil, tt.opts, slog.New(slog.NewTextHandler(&logs, nil)))
+			if tt.wantError {
+				if err == nil {
+					t.Fatal("GenerateOpenAPI() error = nil, want a duplicate operation error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := make(map[string]string)
+			for path, item := range openapi.Paths {
+				var ids []string
+				for _, method := range []string{"GET", "POST"} {
+					if op := pathItemOperation(&item, method); op != nil {
+						ids = append(ids, op.OperationID)
+					}
+				}
+				sort.Strings(ids)
+				got[path] = strings.Join(ids, " ")
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("paths = %v, want %v", got, tt.want)
+			}
+			for path, ids := range tt.want {
+				if got[path] != ids {
+					t.Errorf("operations of %s = %q, want %q", path, got[path], ids)
+				}
+			}
+			if tt.wantWarn != "" && !strings.Contains(logs.String(), tt.wantWarn) {
+				t.Errorf("log does not contain %q:\n%s", tt.wantWarn, logs.String())
+			}
+
+			for path, item := range openapi.Paths {
+				for _, param := range item.Get.Parameters {
+					if param.XWildcard && !strings.Contains(path, "{"+param.Name+"}") {
+						t.Errorf("wildcard parameter %q is not in the path %s", param.Name, path)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// yamlNode is an ordered representation of a JSON value. Objects keep the key order produced
+// by encoding/json (struct field order, sorted map keys), so the YAML output is deterministic.
+type yamlNode struct {
+	keys   []string
+	fields []*yamlNode
+	items  []*yamlNode
+	scalar string
+	kind   yamlKind
+}
+
+type yamlKind int
+
+const (
+	yamlScalar yamlKind = iota
+	yamlMapping
+	yamlSequence
+)
+
+// ToYAML serialises an OpenAPI or Swagger 2.0 document to YAML with the same key order as
+// ToJSON.
+func ToYAML(document interface{}) (string, error) {
+	jsonBytes, err := json.Marshal(document)
+	if err != nil {
+		return "", err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+	decoder.UseNumber()
+	root, err := decodeYAMLNode(decoder)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	writeYAMLNode(&b, root, 0)
+	return b.String(), nil
+}
+
+func decodeYAMLNode(decoder *json.Decoder) (*yamlNode, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := token.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			node := &yamlNode{kind: yamlMapping}
+			for decoder.More() {
+				keyToken, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				key, ok := keyToken.(string)
+				if !ok {
+					return nil, fmt.Errorf("unexpected object key %v", keyToken)
+				}
+				value, err := decodeYAMLNode(decoder)
+				if err != nil {
+					return nil, err
+				}
+				node.keys = append(node.keys, key)
+				node.fields = append(node.fields, value)
+			}
+			_, err := decoder.Token()
+			return node, err
+		case '[':
+			node := &yamlNode{kind: yamlSequence}
+			for decoder.More() {
+				item, err := decodeYAMLNode(decoder)
+				if err != nil {
+					return nil, err
+				}
+				node.items = append(node.items, item)
+			}
+			_, err := decoder.Token()
+			return node, err
+		}
+		return nil, fmt.Errorf("unexpected delimiter %v", t)
+	case string:
+		return &yamlNode{scalar: quoteYAMLString(t)}, nil
+	case json.Number:
+		return &yamlNode{scalar: t.String()}, nil
+	case bool:
+		return &yamlNode{scalar: fmt.Sprintf("%t", t)}, nil
+	case nil:
+		return &yamlNode{scalar: "null"}, nil
+	}
+	return nil, fmt.Errorf("unexpected token %v", token)
+}
+
+// writeYAMLNode writes a mapping or a sequence as a block at the given indentation.
+// Scalars and empty collections are written inline by the caller.
+func writeYAMLNode(w io.StringWriter, node *yamlNode, indent int) {
+	pad := strings.Repeat("  ", indent)
+	switch node.kind {
+	case yamlMapping:
+		for i, key := range node.keys {
+			w.WriteString(pad + quoteYAMLString(key) + ":")
+			writeYAMLValue(w, node.fields[i], indent+1)
+		}
+	case yamlSequence:
+		for _, item := range node.items {
+			w.WriteString(pad + "-")
+			if item.kind == yamlMapping && len(item.keys) > 0 {
+				// The first key of a mapping shares the line with the dash.
+				var nested strings.Builder
+				writeYAMLNode(&nested, item, indent+1)
+				w.WriteString(" " + strings.TrimPrefix(nested.String(), pad+"  "))
+				continue
+			}
+			writeYAMLValue(w, item, indent+1)
+		}
+	default:
+		w.WriteString(pad + node.scalar + "\n")
+	}
+}
+
+// writeYAMLValue writes the value that follows a key or a dash.
+func writeYAMLValue(w io.StringWriter, node *yamlNode, indent int) {
+	switch {
+	case node.kind == yamlMapping && len(node.keys) == 0:
+		w.WriteString(" {}\n")
+	case node.kind == yamlSequence && len(node.items) == 0:
+		w.WriteString(" []\n")
+	case node.kind == yamlScalar:
+		w.WriteString(" " + node.scalar + "\n")
+	default:
+		w.WriteString("\n")
+		writeYAMLNode(w, node, indent)
+	}
+}
+
+// yamlPlainRe matches strings that can be written without quotes.
+var yamlPlainRe = regexp.MustCompile(`^[\p{L}_/$][\p{L}\p{N}_/$.{}()*+, \-]*$`)
+
+// yamlReserved lists plain scalars that YAML parsers would read as non-strings.
+var yamlReserved = map[string]bool{
+	"true": true, "false": true, "yes": true, "no": true, "on": true, "off": true,
+	"null": true, "y": true, "n": true, "~": true,
+}
+
+// quoteYAMLString returns the string as a plain scalar when that is unambiguous
+// and as a double-quoted scalar otherwise.
+func quoteYAMLString(s string) string {
+	if yamlPlainRe.MatchString(s) && !strings.HasSuffix(s, " ") && !yamlReserved[strings.ToLower(s)] {
+		return s
+	}
+
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\x%02x`, r)
+			} else if r == 0x85 || r == 0x2028 || r == 0x2029 || r == 0xfeff {
+				fmt.Fprintf(&b, `\u%04x`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,103 @@
+package generator
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestQuoteYAMLString(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Заказы", "Заказы"},
+		{"/orders/{id}", "/orders/{id}"},
+		{"$ref", "$ref"},
+		{"#/components/schemas/Order", `"#/components/schemas/Order"`},
+		{"200", `"200"`},
+		{"3.0.3", `"3.0.3"`},
+		{"true", `"true"`},
+		{"No", `"No"`},
+		{"", `""`},
+		{"trailing ", `"trailing "`},
+		{"key: value", `"key: value"`},
+		{"a \"quoted\"\nline", `"a \"quoted\"\nline"`},
+		{"bell\a", `"bell\x07"`},
+		{"line\u2028separator", `"line\u2028separator"`},
+	}
+	for _, tt := range tests {
+		if got := quoteYAMLString(tt.in); got != tt.want {
+			t.Errorf("quoteYAMLString(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestToYAML(t *testing.T) {
+	tests := []struct {
+		name     string
+		document interface{}
+		want     string
+	}{
+		{
+			name: "struct fields keep their order",
+			document: struct {
+				OpenAPI string `json:"openapi"`
+				Info    struct {
+					Title   string `json:"title"`
+					Version string `json:"version"`
+				} `json:"info"`
+			}{OpenAPI: "3.0.3", Info: struct {
+				Title   string `json:"title"`
+				Version string `json:"version"`
+			}{Title: "Склад", Version: "1.0"}},
+			want: "openapi: \"3.0.3\"\ninfo:\n  title: Склад\n  version: \"1.0\"\n",
+		},
+		{
+			name:     "map keys are sorted",
+			document: map[string]interface{}{"b": 1, "a": 2.5, "c": nil},
+			want:     "a: 2.5\nb: 1\nc: null\n",
+		},
+		{
+			name: "sequences of mappings",
+			document: map[string]interface{}{
+				"parameters": []interface{}{
+					map[string]interface{}{"name": "id", "in": "path", "required": true},
+					"plain",
+				},
+			},
+			want: "parameters:\n  - in: path\n    name: id\n    required: true\n  - plain\n",
+		},
+		{
+			name:     "empty collections",
+			document: map[string]interface{}{"paths": map[string]interface{}{}, "tags": []interface{}{}},
+			want:     "paths: {}\ntags: []\n",
+		},
+		{
+			name:     "nested sequences",
+			document: map[string]interface{}{"enum": []interface{}{[]interface{}{1, 2}}},
+			want:     "enum:\n  -\n    - 1\n    - 2\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToYAML(tt.document)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("ToYAML() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestToYAMLLargeNumbers checks that numbers are written as they appear in JSON.
+func TestToYAMLLargeNumbers(t *testing.T) {
+	got, err := ToYAML(map[string]interface{}{"maximum": json.Number("9007199254740993")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "maximum: 9007199254740993\n"; got != want {
+		t.Errorf("ToYAML() = %q, want %q", got, want)
+	}
+}
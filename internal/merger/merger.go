@@ -2,84 +2,90 @@ package merger
 
 import "one_c_swagger/internal/reader"
 
+// MergeServices merges extension services into the base ones. The result is deterministic:
+// base objects keep their source order, objects redefined by an extension stay in place, and
+// objects added by extensions are appended in the order the extensions are passed in.
 func MergeServices(baseServices, extServices []reader.HTTPService) []reader.HTTPService {
-	serviceMap := make(map[string]reader.HTTPService)
+	var mergedServices []reader.HTTPService
+	serviceIndex := make(map[string]int)
 
-	// Add base services to the map
+	// Add base services
 	for _, service := range baseServices {
-		serviceMap[service.Properties.Name] = service
+		if i, ok := serviceIndex[service.Properties.Name]; ok {
+			mergedServices[i] = service
+			continue
+		}
+		serviceIndex[service.Properties.Name] = len(mergedServices)
+		mergedServices = append(mergedServices, service)
 	}
 
 	// Merge extension services
 	for _, extService := range extServices {
-		if existingService, ok := serviceMap[extService.Properties.Name]; ok {
+		if i, ok := serviceIndex[extService.Properties.Name]; ok {
 			// Service exists, merge URL templates
-			mergedURLTemplates := mergeURLTemplates(existingService.URLTemplates, extService.URLTemplates)
-			existingService.URLTemplates = mergedURLTemplates
-			serviceMap[extService.Properties.Name] = existingService
+			mergedServices[i].URLTemplates = mergeURLTemplates(mergedServices[i].URLTemplates, extService.URLTemplates)
 		} else {
 			// Service does not exist, add it
-			serviceMap[extService.Properties.Name] = extService
+			serviceIndex[extService.Properties.Name] = len(mergedServices)
+			mergedServices = append(mergedServices, extService)
 		}
 	}
 
-	// Convert map back to slice
-	var mergedServices []reader.HTTPService
-	for _, service := range serviceMap {
-		mergedServices = append(mergedServices, service)
-	}
-
 	return mergedServices
 }
 
 func mergeURLTemplates(baseTemplates, extTemplates []reader.URLTemplate) []reader.URLTemplate {
-	templateMap := make(map[string]reader.URLTemplate)
+	var mergedTemplates []reader.URLTemplate
+	templateIndex := make(map[string]int)
 
-	// Add base templates to the map
+	// Add base templates
 	for _, template := range baseTemplates {
-		templateMap[template.Properties.Template] = template
+		if i, ok := templateIndex[template.Properties.Template]; ok {
+			mergedTemplates[i] = template
+			continue
+		}
+		templateIndex[template.Properties.Template] = len(mergedTemplates)
+		mergedTemplates = append(mergedTemplates, template)
 	}
 
 	// Merge extension templates
 	for _, extTemplate := range extTemplates {
-		if existingTemplate, ok := templateMap[extTemplate.Properties.Template]; ok {
+		if i, ok := templateIndex[extTemplate.Properties.Template]; ok {
 			// Template exists, merge methods
-			mergedMethods := mergeMethods(existingTemplate.Methods, extTemplate.Methods)
-			existingTemplate.Methods = mergedMethods
-			templateMap[extTemplate.Properties.Template] = existingTemplate
+			mergedTemplates[i].Methods = mergeMethods(mergedTemplates[i].Methods, extTemplate.Methods)
 		} else {
 			// Template does not exist, add it
-			templateMap[extTemplate.Properties.Template] = extTemplate
+			templateIndex[extTemplate.Properties.Template] = len(mergedTemplates)
+			mergedTemplates = append(mergedTemplates, extTemplate)
 		}
 	}
 
-	// Convert map back to slice
-	var mergedTemplates []reader.URLTemplate
-	for _, template := range templateMap {
-		mergedTemplates = append(mergedTemplates, template)
-	}
-
 	return mergedTemplates
 }
 
 func mergeMethods(baseMethods, extMethods []reader.Method) []reader.Method {
-	methodMap := make(map[string]reader.Method)
+	var mergedMethods []reader.Method
+	methodIndex := make(map[string]int)
 
-	// Add base methods to the map
+	// Add base methods
 	for _, method := range baseMethods {
-		methodMap[method.Properties.Name] = method
+		if i, ok := methodIndex[method.Properties.Name]; ok {
+			mergedMethods[i] = method
+			continue
+		}
+		methodIndex[method.Properties.Name] = len(mergedMethods)
+		mergedMethods = append(mergedMethods, method)
 	}
 
 	// Merge extension methods
 	for _, extMethod := range extMethods {
 		// Always overwrite, as per "дополнять или переопределять"
-		methodMap[extMethod.Properties.Name] = extMethod
-	}
-
-	// Convert map back to slice
-	var mergedMethods []reader.Method
-	for _, method := range methodMap {
-		mergedMethods = append(mergedMethods, method)
+		if i, ok := methodIndex[extMethod.Properties.Name]; ok {
+			mergedMethods[i] = extMethod
+			continue
+		}
+		methodIndex[extMethod.Properties.Name] = len(mergedMethods)
+		mergedMethods = append(mergedMethods, extMethod)
 	}
 
 	return mergedMethods
@@ -0,0 +1,103 @@
+package merger
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"one_c_swagger/internal/reader"
+)
+
+// service builds a service from "template:method,method" entries; methods come from source.
+func service(name, source string, templates ...string) reader.HTTPService {
+	s := reader.HTTPService{Source: source}
+	s.Properties.Name = name
+	for _, entry := range templates {
+		template, methods, _ := strings.Cut(entry, ":")
+		t := reader.URLTemplate{Source: source}
+		t.Properties.Template = template
+		for _, name := range strings.Split(methods, ",") {
+			m := reader.Method{Source: source}
+			m.Properties.Name = name
+			t.Methods = append(t.Methods, m)
+		}
+		s.URLTemplates = append(s.URLTemplates, t)
+	}
+	return s
+}
+
+// layout lists the merged objects as "service template method@source".
+func layout(services []reader.HTTPService) []string {
+	var objects []string
+	for _, s := range services {
+		for _, t := range s.URLTemplates {
+			for _, m := range t.Methods {
+				objects = append(objects, s.Properties.Name+" "+t.Properties.Template+" "+m.Properties.Name+"@"+m.Source)
+			}
+		}
+	}
+	return objects
+}
+
+func TestMergeServices(t *testing.T) {
+	base := []reader.HTTPService{
+		service("Заказы", "", "/orders:Список,Добавить", "/orders/{id}:Получить,Удалить", "/status:Статус"),
+		service("Склад", "", "/stock:Остатки"),
+	}
+	ext := []reader.HTTPService{
+		service("Заказы", "ext", "/status:Статус", "/orders/{id}:Удалить,Получить", "/orders:Добавить"),
+	}
+	want := []string{
+		"Заказы /orders Список@",
+		"Заказы /orders Добавить@ext",
+		"Заказы /orders/{id} Получить@ext",
+		"Заказы /orders/{id} Удалить@ext",
+		"Заказы /status Статус@ext",
+		"Склад /stock Остатки@",
+	}
+
+	// Redefined objects stay in place, so the order of the extension objects does not matter.
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		shuffled := service("Заказы", "ext")
+		shuffled.URLTemplates = append(shuffled.URLTemplates, ext[0].URLTemplates...)
+		random.Shuffle(len(shuffled.URLTemplates), func(i, j int) {
+			shuffled.URLTemplates[i], shuffled.URLTemplates[j] = shuffled.URLTemplates[j], shuffled.URLTemplates[i]
+		})
+		for j := range shuffled.URLTemplates {
+			methods := append([]reader.Method(nil), shuffled.URLTemplates[j].Methods...)
+			random.Shuffle(len(methods), func(i, j int) { methods[i], methods[j] = methods[j], methods[i] })
+			shuffled.URLTemplates[j].Methods = methods
+		}
+
+		got := layout(MergeServices(base, []reader.HTTPService{shuffled}))
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("merge %d:\n got %q\nwant %q", i, got, want)
+		}
+	}
+}
+
+func TestMergeServicesAppendsInExtensionOrder(t *testing.T) {
+	base := []reader.HTTPService{service("Заказы", "", "/orders:Список")}
+	ext := []reader.HTTPService{
+		service("Заказы", "a", "/orders:Экспорт,Импорт", "/archive:Список"),
+		service("Отчеты", "a", "/report:Сформировать"),
+		service("Заказы", "b", "/orders:Печать"),
+		service("Касса", "b", "/cash:Открыть"),
+	}
+	want := []string{
+		"Заказы /orders Список@",
+		"Заказы /orders Экспорт@a",
+		"Заказы /orders Импорт@a",
+		"Заказы /orders Печать@b",
+		"Заказы /archive Список@a",
+		"Отчеты /report Сформировать@a",
+		"Касса /cash Открыть@b",
+	}
+	for i := 0; i < 20; i++ {
+		if got := layout(MergeServices(base, ext)); !reflect.DeepEqual(got, want) {
+			t.Fatalf("merge %d:\n got %q\nwant %q", i, got, want)
+		}
+	}
+}
@@ -0,0 +1,754 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// unmarshalExtensions returns the fields of the JSON object that the struct type of known does
+// not declare, or nil when there are none. Besides the specification extensions (x-* fields)
+// these are fields the model lacks, such as the JSON Schema keywords of OpenAPI 3.1 overlays
+// (const, $defs, prefixItems, if/then/else); they are kept so that an overlay survives the
+// round trip through the model.
+func unmarshalExtensions(data []byte, known interface{}) (map[string]interface{}, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	names := jsonNames(reflect.TypeOf(known))
+	var extensions map[string]interface{}
+	for key, raw := range fields {
+		if names[key] {
+			continue
+		}
+		var value interface{}
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, err
+		}
+		if extensions == nil {
+			extensions = make(map[string]interface{})
+		}
+		extensions[key] = value
+	}
+	return extensions, nil
+}
+
+// marshalExtensions marshals the struct and appends the extensions, that is all undeclared
+// fields, in key order. Declared fields take precedence over extensions with the same name.
+func marshalExtensions(v interface{}, extensions map[string]interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extensions) == 0 {
+		return data, err
+	}
+
+	names := jsonNames(reflect.TypeOf(v))
+	keys := make([]string, 0, len(extensions))
+	for key := range extensions {
+		if !names[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var b bytes.Buffer
+	b.Write(data[:len(data)-1])
+	empty := len(bytes.TrimSpace(data[1:len(data)-1])) == 0
+	for _, key := range keys {
+		name, _ := json.Marshal(key)
+		value, err := json.Marshal(extensions[key])
+		if err != nil {
+			return nil, err
+		}
+		if !empty {
+			b.WriteByte(',')
+		}
+		empty = false
+		b.Write(name)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// explicitNull is an explicit JSON null of a field that holds any JSON value, such as
+// `example: null` or `default: null`. Unlike a nil interface it is not omitted when the field
+// is marshaled, so a null example survives the round trip through the model.
+var explicitNull = json.RawMessage("null")
+
+// keepNulls sets the fields of the struct v points to that hold any JSON value and are null in
+// the JSON object to explicitNull.
+func keepNulls(data []byte, v interface{}) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	value := reflect.ValueOf(v).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if field.Type.Kind() != reflect.Interface {
+			continue
+		}
+		if raw, ok := fields[name]; ok && bytes.Equal(bytes.TrimSpace(raw), explicitNull) {
+			value.Field(i).Set(reflect.ValueOf(explicitNull))
+		}
+	}
+	return nil
+}
+
+// marshalRef writes a reference object. Objects with required fields are written as the bare
+// reference, since OpenAPI 3.0 ignores the siblings of $ref.
+func marshalRef(ref string) ([]byte, error) {
+	return json.Marshal(map[string]string{"$ref": ref})
+}
+
+func isExtension(key string) bool {
+	return strings.HasPrefix(key, "x-")
+}
+
+// jsonNames returns the JSON names of the fields of a struct type, including the fields of
+// the structs it embeds.
+func jsonNames(typ reflect.Type) map[string]bool {
+	names := make(map[string]bool)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			for embedded := range jsonNames(field.Type) {
+				names[embedded] = true
+			}
+			continue
+		}
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// Callback is written as its reference or as the map of expressions with the extensions.
+
+func (c *Callback) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	*c = Callback{}
+	for key, raw := range fields {
+		switch {
+		case key == "$ref":
+			if err := json.Unmarshal(raw, &c.Ref); err != nil {
+				return err
+			}
+		case isExtension(key):
+			var value interface{}
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return err
+			}
+			if c.Extensions == nil {
+				c.Extensions = make(map[string]interface{})
+			}
+			c.Extensions[key] = value
+		default:
+			var pathItem PathItem
+			if err := json.Unmarshal(raw, &pathItem); err != nil {
+				return err
+			}
+			if c.Expressions == nil {
+				c.Expressions = make(map[string]PathItem)
+			}
+			c.Expressions[key] = pathItem
+		}
+	}
+	return nil
+}
+
+func (c Callback) MarshalJSON() ([]byte, error) {
+	if c.Ref != "" {
+		return marshalRef(c.Ref)
+	}
+	fields := make(map[string]interface{}, len(c.Expressions)+len(c.Extensions))
+	for key, value := range c.Extensions {
+		fields[key] = value
+	}
+	for key, pathItem := range c.Expressions {
+		fields[key] = pathItem
+	}
+	return json.Marshal(fields)
+}
+
+// AdditionalProperties is written as a boolean or as a schema.
+
+func (a *AdditionalProperties) UnmarshalJSON(data []byte) error {
+	*a = AdditionalProperties{}
+	var allowed bool
+	if err := json.Unmarshal(data, &allowed); err == nil {
+		a.Allowed = &allowed
+		return nil
+	}
+	a.Schema = &Schema{}
+	return json.Unmarshal(data, a.Schema)
+}
+
+func (a AdditionalProperties) MarshalJSON() ([]byte, error) {
+	if a.Schema != nil {
+		return json.Marshal(a.Schema)
+	}
+	if a.Allowed != nil {
+		return json.Marshal(*a.Allowed)
+	}
+	return []byte("{}"), nil
+}
+
+func (o *OpenAPI) UnmarshalJSON(data []byte) error {
+	type plain OpenAPI
+	if err := json.Unmarshal(data, (*plain)(o)); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, plain{})
+	o.Extensions = extensions
+	return err
+}
+
+func (o OpenAPI) MarshalJSON() ([]byte, error) {
+	type plain OpenAPI
+	return marshalExtensions(plain(o), o.Extensions)
+}
+
+func (s *Server) UnmarshalJSON(data []byte) error {
+	type plain Server
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, plain{})
+	s.Extensions = extensions
+	return err
+}
+
+func (s Server) MarshalJSON() ([]byte, error) {
+	type plain Server
+	return marshalExtensions(plain(s), s.Extensions)
+}
+
+func (s *ServerVariable) UnmarshalJSON(data []byte) error {
+	type plain ServerVariable
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, plain{})
+	s.Extensions = extensions
+	return err
+}
+
+func (s ServerVariable) MarshalJSON() ([]byte, error) {
+	type plain ServerVariable
+	return marshalExtensions(plain(s), s.Extensions)
+}
+
+func (t *Tag) UnmarshalJSON(data []byte) error {
+	type plain Tag
+	if err := json.Unmarshal(data, (*plain)(t)); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, plain{})
+	t.Extensions = extensions
+	return err
+}
+
+func (t Tag) MarshalJSON() ([]byte, error) {
+	type plain Tag
+	return marshalExtensions(plain(t), t.Extensions)
+}
+
+func (e *ExternalDocs) UnmarshalJSON(data []byte) error {
+	type plain ExternalDocs
+	if err := json.Unmarshal(data, (*plain)(e)); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, plain{})
+	e.Extensions = extensions
+	return err
+}
+
+func (e ExternalDocs) MarshalJSON() ([]byte, error) {
+	type plain ExternalDocs
+	return marshalExtensions(plain(e), e.Extensions)
+}
+
+func (i *Info) UnmarshalJSON(data []byte) error {
+	type plain Info
+	if err := json.Unmarshal(data, (*plain)(i)); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, plain{})
+	i.Extensions = extensions
+	return err
+}
+
+func (i Info) MarshalJSON() ([]byte, error) {
+	type plain Info
+	return marshalExtensions(plain(i), i.Extensions)
+}
+
+func (c *Contact) UnmarshalJSON(data []byte) error {
+	type plain Contact
+	if err := json.Unmarshal(data, (*plain)(c)); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, plain{})
+	c.Extensions = extensions
+	return err
+}
+
+func (c Contact) MarshalJSON() ([]byte, error) {
+	type plain Contact
+	return marshalExtensions(plain(c), c.Extensions)
+}
+
+func (l *License) UnmarshalJSON(data []byte) error {
+	type plain License
+	if err := json.Unmarshal(data, (*plain)(l)); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, plain{})
+	l.Extensions = extensions
+	return err
+}
+
+func (l License) MarshalJSON() ([]byte, error) {
+	type plain License
+	return marshalExtensions(plain(l), l.Extensions)
+}
+
+func (p *PathItem) UnmarshalJSON(data []byte) error {
+	type plain PathItem
+	if err := json.Unmarshal(data, (*plain)(p)); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, plain{})
+	p.Extensions = extensions
+	return err
+}
+
+func (p PathItem) MarshalJSON() ([]byte, error) {
+	type plain PathItem
+	return marshalExtensions(plain(p), p.Extensions)
+}
+
+func (o *Operation) UnmarshalJSON(data []byte) error {
+	type plain Operation
+	if err := json.Unmarshal(data, (*plain)(o)); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, plain{})
+	o.Extensions = extensions
+	return err
+}
+
+func (o Operation) MarshalJSON() ([]byte, error) {
+	type plain Operation
+	return marshalExtensions(plain(o), o.Extensions)
+}
+
+func (p *Parameter) UnmarshalJSON(data []byte) error {
+	type plain Parameter
+	if err := json.Unmarshal(data, (*plain)(p)); err != nil {
+		return err
+	}
+	if err := keepNulls(data, (*plain)(p)); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, plain{})
+	p.Extensions = extensions
+	return err
+}
+
+func (p Parameter) MarshalJSON() ([]byte, error) {
+	if p.Ref != "" {
+		return marshalRef(p.Ref)
+	}
+	type plain Parameter
+	return marshalExtensions(plain(p), p.Extensions)
+}
+
+func (r *RequestBody) UnmarshalJSON(data []byte) error {
+	type plain RequestBody
+	if err := json.Unmarshal(data, (*plain)(r)); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, plain{})
+	r.Extensions = extensions
+	return err
+}
+
+func (r RequestBody) MarshalJSON() ([]byte, error) {
+	if r.Ref != "" {
+		return marshalRef(r.Ref)
+	}
+	type plain RequestBody
+	return marshalExtensions(plain(r), r.Extensions)
+}
+
+func (m *MediaType) UnmarshalJSON(data []byte) error {
+	type plain MediaType
+	if err := json.Unmarshal(data, (*plain)(m)); err != nil {
+		return err
+	}
+	if err := keepNulls(data, (*plain)(m)); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, plain{})
+	m.Extensions = extensions
+	return err
+}
+
+func (m MediaType) MarshalJSON() ([]byte, error) {
+	type plain MediaType
+	return marshalExtensions(plain(m), m.Extensions)
+}
+
+func (e *Encoding) UnmarshalJSON(data []byte) error {
+	type plain Encoding
+	if err := json.Unmarshal(data, (*plain)(e)); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, plain{})
+	e.Extensions = extensions
+	return err
+}
+
+func (e Encoding) MarshalJSON() ([]byte, error) {
+	type plain Encoding
+	return marshalExtensions(plain(e), e.Extensions)
+}
+
+func (r *Response) UnmarshalJSON(data []byte) error {
+	type plain Response
+	if err := json.Unmarshal(data, (*plain)(r)); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, plain{})
+	r.Extensions = extensions
+	return err
+}
+
+func (r Response) MarshalJSON() ([]byte, error) {
+	if r.Ref != "" {
+		return marshalRef(r.Ref)
+	}
+	type plain Response
+	return marshalExtensions(plain(r), r.Extensions)
+}
+
+func (e *Example) UnmarshalJSON(data []byte) error {
+	type plain Example
+	if err := json.Unmarshal(data, (*plain)(e)); err != nil {
+		return err
+	}
+	if err := keepNulls(data, (*plain)(e)); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, plain{})
+	e.Extensions = extensions
+	return err
+}
+
+func (e Example) MarshalJSON() ([]byte, error) {
+	type plain Example
+	return marshalExtensions(plain(e), e.Extensions)
+}
+
+func (l *Link) UnmarshalJSON(data []byte) error {
+	type plain Link
+	if err := json.Unmarshal(data, (*plain)(l)); err != nil {
+		return err
+	}
+	if err := keepNulls(data, (*plain)(l)); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, plain{})
+	l.Extensions = extensions
+	return err
+}
+
+func (l Link) MarshalJSON() ([]byte, error) {
+	type plain Link
+	return marshalExtensions(plain(l), l.Extensions)
+}
+
+func (h *Header) UnmarshalJSON(data []byte) error {
+	type plain Header
+	if err := json.Unmarshal(data, (*plain)(h)); err != nil {
+		return err
+	}
+	if err := keepNulls(data, (*plain)(h)); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, plain{})
+	h.Extensions = extensions
+	return err
+}
+
+func (h Header) MarshalJSON() ([]byte, error) {
+	type plain Header
+	return marshalExtensions(plain(h), h.Extensions)
+}
+
+func (c *Components) UnmarshalJSON(data []byte) error {
+	type plain Components
+	if err := json.Unmarshal(data, (*plain)(c)); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, plain{})
+	c.Extensions = extensions
+	return err
+}
+
+func (c Components) MarshalJSON() ([]byte, error) {
+	type plain Components
+	return marshalExtensions(plain(c), c.Extensions)
+}
+
+func (s *SecurityScheme) UnmarshalJSON(data []byte) error {
+	type plain SecurityScheme
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, plain{})
+	s.Extensions = extensions
+	return err
+}
+
+func (s SecurityScheme) MarshalJSON() ([]byte, error) {
+	if s.Ref != "" {
+		return marshalRef(s.Ref)
+	}
+	type plain SecurityScheme
+	return marshalExtensions(plain(s), s.Extensions)
+}
+
+func (o *OAuthFlows) UnmarshalJSON(data []byte) error {
+	type plain OAuthFlows
+	if err := json.Unmarshal(data, (*plain)(o)); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, plain{})
+	o.Extensions = extensions
+	return err
+}
+
+func (o OAuthFlows) MarshalJSON() ([]byte, error) {
+	type plain OAuthFlows
+	return marshalExtensions(plain(o), o.Extensions)
+}
+
+func (o *OAuthFlow) UnmarshalJSON(data []byte) error {
+	type plain OAuthFlow
+	if err := json.Unmarshal(data, (*plain)(o)); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, plain{})
+	o.Extensions = extensions
+	return err
+}
+
+func (o OAuthFlow) MarshalJSON() ([]byte, error) {
+	type plain OAuthFlow
+	return marshalExtensions(plain(o), o.Extensions)
+}
+
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	type plain Schema
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	if err := keepNulls(data, (*plain)(s)); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, plain{})
+	s.Extensions = extensions
+	return err
+}
+
+func (s Schema) MarshalJSON() ([]byte, error) {
+	type plain Schema
+	return marshalExtensions(plain(s), s.Extensions)
+}
+
+func (x *XML) UnmarshalJSON(data []byte) error {
+	type plain XML
+	if err := json.Unmarshal(data, (*plain)(x)); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, plain{})
+	x.Extensions = extensions
+	return err
+}
+
+func (x XML) MarshalJSON() ([]byte, error) {
+	type plain XML
+	return marshalExtensions(plain(x), x.Extensions)
+}
+
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var typ string
+	if err := json.Unmarshal(data, &typ); err == nil {
+		*t = Types{typ}
+		return nil
+	}
+	var types []string
+	if err := json.Unmarshal(data, &types); err != nil {
+		return err
+	}
+	*t = types
+	return nil
+}
+
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+func (b *ExclusiveBound) UnmarshalJSON(data []byte) error {
+	*b = ExclusiveBound{}
+	if err := json.Unmarshal(data, &b.Exclusive); err == nil {
+		return nil
+	}
+	return json.Unmarshal(data, &b.Value)
+}
+
+func (b ExclusiveBound) MarshalJSON() ([]byte, error) {
+	if b.Value != nil {
+		return json.Marshal(*b.Value)
+	}
+	return json.Marshal(b.Exclusive)
+}
+
+func (s *Swagger) UnmarshalJSON(data []byte) error {
+	type plain Swagger
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, plain{})
+	s.Extensions = extensions
+	return err
+}
+
+func (s Swagger) MarshalJSON() ([]byte, error) {
+	type plain Swagger
+	return marshalExtensions(plain(s), s.Extensions)
+}
+
+func (s *SwaggerPathItem) UnmarshalJSON(data []byte) error {
+	type plain SwaggerPathItem
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, plain{})
+	s.Extensions = extensions
+	return err
+}
+
+func (s SwaggerPathItem) MarshalJSON() ([]byte, error) {
+	type plain SwaggerPathItem
+	return marshalExtensions(plain(s), s.Extensions)
+}
+
+func (s *SwaggerOperation) UnmarshalJSON(data []byte) error {
+	type plain SwaggerOperation
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, plain{})
+	s.Extensions = extensions
+	return err
+}
+
+func (s SwaggerOperation) MarshalJSON() ([]byte, error) {
+	type plain SwaggerOperation
+	return marshalExtensions(plain(s), s.Extensions)
+}
+
+func (s *SwaggerParameter) UnmarshalJSON(data []byte) error {
+	type plain SwaggerParameter
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, plain{})
+	s.Extensions = extensions
+	return err
+}
+
+func (s SwaggerParameter) MarshalJSON() ([]byte, error) {
+	if s.Ref != "" {
+		return marshalRef(s.Ref)
+	}
+	type plain SwaggerParameter
+	return marshalExtensions(plain(s), s.Extensions)
+}
+
+func (s *SwaggerResponse) UnmarshalJSON(data []byte) error {
+	type plain SwaggerResponse
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, plain{})
+	s.Extensions = extensions
+	return err
+}
+
+func (s SwaggerResponse) MarshalJSON() ([]byte, error) {
+	if s.Ref != "" {
+		return marshalRef(s.Ref)
+	}
+	type plain SwaggerResponse
+	return marshalExtensions(plain(s), s.Extensions)
+}
+
+func (s *SwaggerHeader) UnmarshalJSON(data []byte) error {
+	type plain SwaggerHeader
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, plain{})
+	s.Extensions = extensions
+	return err
+}
+
+func (s SwaggerHeader) MarshalJSON() ([]byte, error) {
+	type plain SwaggerHeader
+	return marshalExtensions(plain(s), s.Extensions)
+}
+
+func (s *SwaggerSecurityScheme) UnmarshalJSON(data []byte) error {
+	type plain SwaggerSecurityScheme
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(data, plain{})
+	s.Extensions = extensions
+	return err
+}
+
+func (s SwaggerSecurityScheme) MarshalJSON() ([]byte, error) {
+	type plain SwaggerSecurityScheme
+	if s.Type != "oauth2" {
+		return marshalExtensions(plain(s), s.Extensions)
+	}
+	// OAuth2 definitions require scopes, which may be empty.
+	type oauth2 struct {
+		plain
+		Scopes map[string]string `json:"scopes"`
+	}
+	scopes := s.Scopes
+	if scopes == nil {
+		scopes = map[string]string{}
+	}
+	return marshalExtensions(oauth2{plain(s), scopes}, s.Extensions)
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestUnknownFields(t *testing.T) {
+	tests := []struct {
+		name       string
+		source     string
+		value      interface{}
+		extensions func(v interface{}) map[string]interface{}
+		want       map[string]interface{}
+	}{
+		{
+			name:       "operation",
+			source:     `{"operationId": "op", "responses": {}, "x-audit": {"owner": "billing"}, "x-1c-name": "Биллинг.Версия.GET", "servers2": [1]}`,
+			value:      &Operation{},
+			extensions: func(v interface{}) map[string]interface{} { return v.(*Operation).Extensions },
+			want:       map[string]interface{}{"x-audit": map[string]interface{}{"owner": "billing"}, "servers2": []interface{}{1.0}},
+		},
+		{
+			name:       "parameter",
+			source:     `{"name": "id", "in": "query", "schema": {"type": "string"}, "x-example-id": 7, "content-hint": "id"}`,
+			value:      &Parameter{},
+			extensions: func(v interface{}) map[string]interface{} { return v.(*Parameter).Extensions },
+			want:       map[string]interface{}{"x-example-id": 7.0, "content-hint": "id"},
+		},
+		{
+			name:       "response",
+			source:     `{"description": "OK", "x-1c-inferred": true, "x-cache": false, "summary": "unknown in 3.0"}`,
+			value:      &Response{},
+			extensions: func(v interface{}) map[string]interface{} { return v.(*Response).Extensions },
+			want:       map[string]interface{}{"x-cache": false, "summary": "unknown in 3.0"},
+		},
+		{
+			name:       "media type",
+			source:     `{"schema": {"type": "string"}, "itemSchema": {"type": "integer"}, "x-null": null}`,
+			value:      &MediaType{},
+			extensions: func(v interface{}) map[string]interface{} { return v.(*MediaType).Extensions },
+			want:       map[string]interface{}{"itemSchema": map[string]interface{}{"type": "integer"}, "x-null": nil},
+		},
+		{
+			name:       "declared fields only",
+			source:     `{"name": "id", "in": "path", "required": true}`,
+			value:      &Parameter{},
+			extensions: func(v interface{}) map[string]interface{} { return v.(*Parameter).Extensions },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := json.Unmarshal([]byte(tt.source), tt.value); err != nil {
+				t.Fatal(err)
+			}
+			if got := tt.extensions(tt.value); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Extensions = %#v, want %#v", got, tt.want)
+			}
+
+			data, err := json.Marshal(tt.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !equalJSON(t, data, []byte(tt.source)) {
+				t.Errorf("round trip = %s, want %s", data, tt.source)
+			}
+		})
+	}
+}
+
+// TestExtensionsNeverDuplicateFields checks that a key of a declared field set in Extensions
+// by hand is not written a second time: the declared field wins.
+func TestExtensionsNeverDuplicateFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{
+			name: "operation",
+			value: Operation{
+				OperationID: "op",
+				Responses:   Responses{},
+				Extensions:  map[string]interface{}{"operationId": "other", "responses": nil, "x-a": 1},
+			},
+			want: `{"operationId":"op","responses":{},"x-a":1}`,
+		},
+		{
+			name: "parameter",
+			value: Parameter{
+				Name:       "id",
+				In:         "query",
+				Extensions: map[string]interface{}{"name": "other", "in": "header", "x-1c-name": "Ид"},
+			},
+			want: `{"name":"id","in":"query"}`,
+		},
+		{
+			name: "response",
+			value: Response{
+				Description: "OK",
+				Extensions:  map[string]interface{}{"description": "other", "content": map[string]interface{}{}},
+			},
+			want: `{"description":"OK"}`,
+		},
+		{
+			name: "media type",
+			value: MediaType{
+				Schema:     &Schema{Type: Types{"string"}},
+				Extensions: map[string]interface{}{"schema": map[string]interface{}{}, "example": 1},
+			},
+			want: `{"schema":{"type":"string"}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(data, []byte(tt.want)) {
+				t.Errorf("json.Marshal() = %s, want %s", data, tt.want)
+			}
+		})
+	}
+}
@@ -1,107 +1,352 @@
 package models
 
+// The types below model OpenAPI 3.0, with the few additions needed to write OpenAPI 3.1
+// (webhooks, type lists, numeric exclusive bounds, schema examples). Every object that the
+// specification allows to be extended keeps its x-* fields in Extensions, together with any
+// other field the model does not declare, so nothing an overlay contains is lost; objects that
+// may be replaced by a reference carry it in Ref. The fields of schemas, request bodies, responses
+// and the objects they contain are in alphabetical order of their JSON names, so they are
+// written with sorted keys like the overlay JSON they usually come from.
+
 type OpenAPI struct {
-	OpenAPI    string               `json:"openapi"`
-	Info       Info                 `json:"info"`
-	Servers    []Server             `json:"servers,omitempty"`
-	Tags       []Tag                `json:"tags,omitempty"`
-	Paths      map[string]PathItem  `json:"paths"`
-	Components Components           `json:"components"`
+	OpenAPI           string                 `json:"openapi"`
+	Info              Info                   `json:"info"`
+	JSONSchemaDialect string                 `json:"jsonSchemaDialect,omitempty"`
+	Servers           []Server               `json:"servers,omitempty"`
+	Tags              []Tag                  `json:"tags,omitempty"`
+	Paths             map[string]PathItem    `json:"paths"`
+	Webhooks          map[string]PathItem    `json:"webhooks,omitempty"`
+	Components        Components             `json:"components"`
+	Security          []SecurityRequirement  `json:"security,omitempty"`
+	ExternalDocs      *ExternalDocs          `json:"externalDocs,omitempty"`
+	Extensions        map[string]interface{} `json:"-"`
 }
 
 type Server struct {
-	URL         string `json:"url"`
-	Description string `json:"description,omitempty"`
+	URL         string                    `json:"url"`
+	Description string                    `json:"description,omitempty"`
+	Variables   map[string]ServerVariable `json:"variables,omitempty"`
+	Extensions  map[string]interface{}    `json:"-"`
+}
+
+type ServerVariable struct {
+	Enum        []string               `json:"enum,omitempty"`
+	Default     string                 `json:"default"`
+	Description string                 `json:"description,omitempty"`
+	Extensions  map[string]interface{} `json:"-"`
 }
 
 type Tag struct {
-	Name        string `json:"name"`
-	Description string `json:"description,omitempty"`
+	Name         string                 `json:"name"`
+	Description  string                 `json:"description,omitempty"`
+	ExternalDocs *ExternalDocs          `json:"externalDocs,omitempty"`
+	XName        string                 `json:"x-1c-name,omitempty"`
+	Extensions   map[string]interface{} `json:"-"`
+}
+
+type ExternalDocs struct {
+	Description string                 `json:"description,omitempty"`
+	URL         string                 `json:"url"`
+	Extensions  map[string]interface{} `json:"-"`
 }
 
 type Info struct {
-	Title   string `json:"title"`
-	Version string `json:"version"`
+	Title          string                 `json:"title"`
+	Summary        string                 `json:"summary,omitempty"`
+	Description    string                 `json:"description,omitempty"`
+	TermsOfService string                 `json:"termsOfService,omitempty"`
+	Contact        *Contact               `json:"contact,omitempty"`
+	License        *License               `json:"license,omitempty"`
+	Version        string                 `json:"version"`
+	Extensions     map[string]interface{} `json:"-"`
+}
+
+type Contact struct {
+	Name       string                 `json:"name,omitempty"`
+	URL        string                 `json:"url,omitempty"`
+	Email      string                 `json:"email,omitempty"`
+	Extensions map[string]interface{} `json:"-"`
+}
+
+type License struct {
+	Name       string                 `json:"name"`
+	Identifier string                 `json:"identifier,omitempty"`
+	URL        string                 `json:"url,omitempty"`
+	Extensions map[string]interface{} `json:"-"`
 }
 
 type PathItem struct {
-	Get       *Operation `json:"get,omitempty"`
-	Post      *Operation `json:"post,omitempty"`
-	Put       *Operation `json:"put,omitempty"`
-	Delete    *Operation `json:"delete,omitempty"`
-	Head      *Operation `json:"head,omitempty"`
-	Patch     *Operation `json:"patch,omitempty"`
-	Merge     *Operation `json:"merge,omitempty"`
-	Options   *Operation `json:"options,omitempty"`
-	Trace     *Operation `json:"trace,omitempty"`
-	Connect   *Operation `json:"connect,omitempty"`
-	Propfind  *Operation `json:"propfind,omitempty"`
-	Proppatch *Operation `json:"proppatch,omitempty"`
-	Move      *Operation `json:"move,omitempty"`
-	Copy      *Operation `json:"copy,omitempty"`
-	Lock      *Operation `json:"lock,omitempty"`
-	Unlock    *Operation `json:"unlock,omitempty"`
-	Mkcol     *Operation `json:"mkcol,omitempty"`
-	XAnyMethod bool      `json:"x-any-method,omitempty"`
+	Ref         string                 `json:"$ref,omitempty"`
+	Summary     string                 `json:"summary,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Servers     []Server               `json:"servers,omitempty"`
+	Parameters  []Parameter            `json:"parameters,omitempty"`
+	Get         *Operation             `json:"get,omitempty"`
+	Post        *Operation             `json:"post,omitempty"`
+	Put         *Operation             `json:"put,omitempty"`
+	Delete      *Operation             `json:"delete,omitempty"`
+	Head        *Operation             `json:"head,omitempty"`
+	Patch       *Operation             `json:"patch,omitempty"`
+	Merge       *Operation             `json:"merge,omitempty"`
+	Options     *Operation             `json:"options,omitempty"`
+	Trace       *Operation             `json:"trace,omitempty"`
+	Connect     *Operation             `json:"connect,omitempty"`
+	Propfind    *Operation             `json:"propfind,omitempty"`
+	Proppatch   *Operation             `json:"proppatch,omitempty"`
+	Move        *Operation             `json:"move,omitempty"`
+	Copy        *Operation             `json:"copy,omitempty"`
+	Lock        *Operation             `json:"lock,omitempty"`
+	Unlock      *Operation             `json:"unlock,omitempty"`
+	Mkcol       *Operation             `json:"mkcol,omitempty"`
+	XAnyMethod  bool                   `json:"x-any-method,omitempty"`
+	XWildcard   bool                   `json:"x-1c-wildcard,omitempty"`
+	Extensions  map[string]interface{} `json:"-"`
 }
 
 type Operation struct {
-	Tags        []string              `json:"tags,omitempty"`
-	Summary     string                `json:"summary"`
-	OperationID string                `json:"operationId"`
-	Parameters  []Parameter           `json:"parameters,omitempty"`
-	RequestBody interface{}           `json:"requestBody,omitempty"`
-	Responses   Responses             `json:"responses"`
-	Security    []SecurityRequirement `json:"security,omitempty"`
-	Servers     []Server              `json:"servers,omitempty"`
+	Tags         []string               `json:"tags,omitempty"`
+	Summary      string                 `json:"summary,omitempty"`
+	Description  string                 `json:"description,omitempty"`
+	ExternalDocs *ExternalDocs          `json:"externalDocs,omitempty"`
+	OperationID  string                 `json:"operationId,omitempty"`
+	Parameters   []Parameter            `json:"parameters,omitempty"`
+	RequestBody  *RequestBody           `json:"requestBody,omitempty"`
+	Responses    Responses              `json:"responses"`
+	Callbacks    map[string]Callback    `json:"callbacks,omitempty"`
+	Deprecated   bool                   `json:"deprecated,omitempty"`
+	Security     []SecurityRequirement  `json:"security,omitempty"`
+	Servers      []Server               `json:"servers,omitempty"`
+	XName        string                 `json:"x-1c-name,omitempty"`
+	Extensions   map[string]interface{} `json:"-"`
 }
 
-type Responses map[string]interface{}
+type Parameter struct {
+	Ref             string                 `json:"$ref,omitempty"`
+	Name            string                 `json:"name"`
+	In              string                 `json:"in"`
+	Description     string                 `json:"description,omitempty"`
+	Required        bool                   `json:"required,omitempty"`
+	Deprecated      bool                   `json:"deprecated,omitempty"`
+	AllowEmptyValue bool                   `json:"allowEmptyValue,omitempty"`
+	Style           string                 `json:"style,omitempty"`
+	Explode         *bool                  `json:"explode,omitempty"`
+	AllowReserved   bool                   `json:"allowReserved,omitempty"`
+	Schema          *Schema                `json:"schema,omitempty"`
+	Example         interface{}            `json:"example,omitempty"`
+	Examples        map[string]Example     `json:"examples,omitempty"`
+	Content         map[string]MediaType   `json:"content,omitempty"`
+	XWildcard       bool                   `json:"x-1c-wildcard,omitempty"`
+	XName           string                 `json:"x-1c-name,omitempty"`
+	XInferred       bool                   `json:"x-1c-inferred,omitempty"`
+	Extensions      map[string]interface{} `json:"-"`
+}
+
+type RequestBody struct {
+	Ref         string                 `json:"$ref,omitempty"`
+	Content     map[string]MediaType   `json:"content"`
+	Description string                 `json:"description,omitempty"`
+	Required    bool                   `json:"required,omitempty"`
+	XInferred   bool                   `json:"x-1c-inferred,omitempty"`
+	Extensions  map[string]interface{} `json:"-"`
+}
+
+type MediaType struct {
+	Encoding   map[string]Encoding    `json:"encoding,omitempty"`
+	Example    interface{}            `json:"example,omitempty"`
+	Examples   map[string]Example     `json:"examples,omitempty"`
+	Schema     *Schema                `json:"schema,omitempty"`
+	Extensions map[string]interface{} `json:"-"`
+}
+
+type Encoding struct {
+	AllowReserved bool                   `json:"allowReserved,omitempty"`
+	ContentType   string                 `json:"contentType,omitempty"`
+	Explode       *bool                  `json:"explode,omitempty"`
+	Headers       map[string]Header      `json:"headers,omitempty"`
+	Style         string                 `json:"style,omitempty"`
+	Extensions    map[string]interface{} `json:"-"`
+}
+
+// Responses maps status codes and "default" to responses.
+type Responses map[string]*Response
 
 type Response struct {
-	Description string                 `json:"description"`
-	Headers     map[string]interface{} `json:"headers,omitempty"`
+	Ref         string                 `json:"$ref,omitempty"`
 	Content     map[string]MediaType   `json:"content,omitempty"`
+	Description string                 `json:"description"`
+	Headers     map[string]Header      `json:"headers,omitempty"`
+	Links       map[string]Link        `json:"links,omitempty"`
+	XInferred   bool                   `json:"x-1c-inferred,omitempty"`
+	Extensions  map[string]interface{} `json:"-"`
 }
 
-type Header struct {
-	Description string    `json:"description,omitempty"`
-	Schema      SchemaRef `json:"schema"`
+// Callback maps runtime expressions to the path items of the requests the API may send. A
+// callback is either a reference or a set of expressions.
+type Callback struct {
+	Ref         string
+	Expressions map[string]PathItem
+	Extensions  map[string]interface{}
 }
 
-type MediaType struct {
-	Schema  interface{} `json:"schema,omitempty"`
-	Example interface{} `json:"example,omitempty"`
+type Example struct {
+	Ref           string                 `json:"$ref,omitempty"`
+	Description   string                 `json:"description,omitempty"`
+	ExternalValue string                 `json:"externalValue,omitempty"`
+	Summary       string                 `json:"summary,omitempty"`
+	Value         interface{}            `json:"value,omitempty"`
+	Extensions    map[string]interface{} `json:"-"`
+}
+
+type Link struct {
+	Ref          string                 `json:"$ref,omitempty"`
+	Description  string                 `json:"description,omitempty"`
+	OperationID  string                 `json:"operationId,omitempty"`
+	OperationRef string                 `json:"operationRef,omitempty"`
+	Parameters   map[string]interface{} `json:"parameters,omitempty"`
+	RequestBody  interface{}            `json:"requestBody,omitempty"`
+	Server       *Server                `json:"server,omitempty"`
+	Extensions   map[string]interface{} `json:"-"`
+}
+
+type Header struct {
+	Ref             string                 `json:"$ref,omitempty"`
+	AllowEmptyValue bool                   `json:"allowEmptyValue,omitempty"`
+	AllowReserved   bool                   `json:"allowReserved,omitempty"`
+	Content         map[string]MediaType   `json:"content,omitempty"`
+	Deprecated      bool                   `json:"deprecated,omitempty"`
+	Description     string                 `json:"description,omitempty"`
+	Example         interface{}            `json:"example,omitempty"`
+	Examples        map[string]Example     `json:"examples,omitempty"`
+	Explode         *bool                  `json:"explode,omitempty"`
+	Required        bool                   `json:"required,omitempty"`
+	Schema          *Schema                `json:"schema,omitempty"`
+	Style           string                 `json:"style,omitempty"`
+	Extensions      map[string]interface{} `json:"-"`
 }
 
 type Components struct {
-	Schemas         map[string]interface{}    `json:"schemas,omitempty"`
+	Schemas         map[string]*Schema        `json:"schemas,omitempty"`
 	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes"`
 	Parameters      map[string]Parameter      `json:"parameters,omitempty"`
 	Headers         map[string]Header         `json:"headers,omitempty"`
-	Responses       map[string]interface{}    `json:"responses,omitempty"`
+	Responses       map[string]*Response      `json:"responses,omitempty"`
+	Examples        map[string]Example        `json:"examples,omitempty"`
+	RequestBodies   map[string]RequestBody    `json:"requestBodies,omitempty"`
+	Links           map[string]Link           `json:"links,omitempty"`
+	Callbacks       map[string]Callback       `json:"callbacks,omitempty"`
+	Extensions      map[string]interface{}    `json:"-"`
 }
 
 type SecurityScheme struct {
-	Type         string `json:"type"`
-	Description  string `json:"description,omitempty"`
-	Name         string `json:"name,omitempty"`
-	In           string `json:"in,omitempty"`
-	Scheme       string `json:"scheme,omitempty"`
-	BearerFormat string `json:"bearerFormat,omitempty"`
+	Ref              string                 `json:"$ref,omitempty"`
+	Type             string                 `json:"type"`
+	Description      string                 `json:"description,omitempty"`
+	Name             string                 `json:"name,omitempty"`
+	In               string                 `json:"in,omitempty"`
+	Scheme           string                 `json:"scheme,omitempty"`
+	BearerFormat     string                 `json:"bearerFormat,omitempty"`
+	Flows            *OAuthFlows            `json:"flows,omitempty"`
+	OpenIDConnectURL string                 `json:"openIdConnectUrl,omitempty"`
+	Extensions       map[string]interface{} `json:"-"`
+}
+
+type OAuthFlows struct {
+	Implicit          *OAuthFlow             `json:"implicit,omitempty"`
+	Password          *OAuthFlow             `json:"password,omitempty"`
+	ClientCredentials *OAuthFlow             `json:"clientCredentials,omitempty"`
+	AuthorizationCode *OAuthFlow             `json:"authorizationCode,omitempty"`
+	Extensions        map[string]interface{} `json:"-"`
+}
+
+type OAuthFlow struct {
+	AuthorizationURL string                 `json:"authorizationUrl,omitempty"`
+	TokenURL         string                 `json:"tokenUrl,omitempty"`
+	RefreshURL       string                 `json:"refreshUrl,omitempty"`
+	Scopes           map[string]string      `json:"scopes"`
+	Extensions       map[string]interface{} `json:"-"`
 }
 
 type SecurityRequirement map[string][]string
 
-type Parameter struct {
-	Name        string      `json:"name"`
-	In          string      `json:"in"`
-	Description string      `json:"description,omitempty"`
-	Required    bool        `json:"required,omitempty"`
-	Schema      *SchemaRef  `json:"schema,omitempty"`
+// Schema is the OpenAPI 3.0 schema object.
+type Schema struct {
+	Ref                  string                 `json:"$ref,omitempty"`
+	AdditionalProperties *AdditionalProperties  `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema              `json:"allOf,omitempty"`
+	AnyOf                []*Schema              `json:"anyOf,omitempty"`
+	Default              interface{}            `json:"default,omitempty"`
+	Deprecated           bool                   `json:"deprecated,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Discriminator        *Discriminator         `json:"discriminator,omitempty"`
+	Enum                 []interface{}          `json:"enum,omitempty"`
+	Example              interface{}            `json:"example,omitempty"`
+	Examples             []interface{}          `json:"examples,omitempty"`
+	ExclusiveMaximum     *ExclusiveBound        `json:"exclusiveMaximum,omitempty"`
+	ExclusiveMinimum     *ExclusiveBound        `json:"exclusiveMinimum,omitempty"`
+	ExternalDocs         *ExternalDocs          `json:"externalDocs,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Items                *Schema                `json:"items,omitempty"`
+	MaxItems             *uint64                `json:"maxItems,omitempty"`
+	MaxLength            *uint64                `json:"maxLength,omitempty"`
+	MaxProperties        *uint64                `json:"maxProperties,omitempty"`
+	Maximum              *float64               `json:"maximum,omitempty"`
+	MinItems             *uint64                `json:"minItems,omitempty"`
+	MinLength            *uint64                `json:"minLength,omitempty"`
+	MinProperties        *uint64                `json:"minProperties,omitempty"`
+	Minimum              *float64               `json:"minimum,omitempty"`
+	MultipleOf           *float64               `json:"multipleOf,omitempty"`
+	Not                  *Schema                `json:"not,omitempty"`
+	Nullable             bool                   `json:"nullable,omitempty"`
+	OneOf                []*Schema              `json:"oneOf,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	Properties           map[string]*Schema     `json:"properties,omitempty"`
+	ReadOnly             bool                   `json:"readOnly,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Type                 Types                  `json:"type,omitempty"`
+	UniqueItems          bool                   `json:"uniqueItems,omitempty"`
+	WriteOnly            bool                   `json:"writeOnly,omitempty"`
+	XML                  *XML                   `json:"xml,omitempty"`
+	Extensions           map[string]interface{} `json:"-"`
+}
+
+// Types is the type of a schema. OpenAPI 3.0 allows a single type, written as a string;
+// OpenAPI 3.1 allows a list, e.g. ["string", "null"].
+type Types []string
+
+// Includes reports whether typ is one of the types.
+func (t Types) Includes(typ string) bool {
+	for _, item := range t {
+		if item == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// ExclusiveBound is exclusiveMaximum or exclusiveMinimum: a flag that makes maximum or
+// minimum exclusive in OpenAPI 3.0, the bound itself in OpenAPI 3.1.
+type ExclusiveBound struct {
+	Exclusive bool
+	Value     *float64
+}
+
+// AdditionalProperties is either a boolean or a schema.
+type AdditionalProperties struct {
+	Allowed *bool
+	Schema  *Schema
+}
+
+type Discriminator struct {
+	PropertyName string            `json:"propertyName"`
+	Mapping      map[string]string `json:"mapping,omitempty"`
 }
 
-type SchemaRef struct {
-	Type   string `json:"type"`
-	Format string `json:"format,omitempty"`
+type XML struct {
+	Name       string                 `json:"name,omitempty"`
+	Namespace  string                 `json:"namespace,omitempty"`
+	Prefix     string                 `json:"prefix,omitempty"`
+	Attribute  bool                   `json:"attribute,omitempty"`
+	Wrapped    bool                   `json:"wrapped,omitempty"`
+	Extensions map[string]interface{} `json:"-"`
 }
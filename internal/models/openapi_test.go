@@ -0,0 +1,157 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// roundTrip decodes the document into the model and encodes it again.
+func roundTrip(t *testing.T, document string) []byte {
+	t.Helper()
+	var openapi OpenAPI
+	if err := json.Unmarshal([]byte(document), &openapi); err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(openapi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// equalJSON reports whether two JSON documents hold the same values.
+func equalJSON(t *testing.T, a, b []byte) bool {
+	t.Helper()
+	var treeA, treeB interface{}
+	if err := json.Unmarshal(a, &treeA); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, &treeB); err != nil {
+		t.Fatal(err)
+	}
+	return reflect.DeepEqual(treeA, treeB)
+}
+
+func TestOpenAPIRoundTrip(t *testing.T) {
+	const document = `{
+		"openapi": "3.1.0",
+		"info": {"title": "API", "version": "1", "x-logo": {"url": "logo.png"}},
+		"jsonSchemaDialect": "https://spec.openapis.org/oas/3.1/dialect/base",
+		"x-root": [1, "two", null],
+		"paths": {
+			"/orders/{id}": {
+				"summary": "Заказ",
+				"x-path": true,
+				"parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}, "x-param": "p"}],
+				"post": {
+					"operationId": "updateOrder",
+					"x-operation": {"nested": [true]},
+					"unknownField": "kept",
+					"parameters": [
+						{"name": "mode", "in": "query", "example": null, "schema": {"type": ["string", "null"], "default": null, "example": null}},
+						{"name": "limit", "in": "query", "example": 0, "schema": {"type": "integer", "default": 10, "minimum": 0, "exclusiveMinimum": true, "maximum": 100, "exclusiveMaximum": false}}
+					],
+					"requestBody": {
+						"content": {"application/json": {
+							"schema": {"$ref": "#/components/schemas/Order"},
+							"example": null,
+							"examples": {"empty": {"summary": "Пусто", "value": null, "x-example": 1}},
+							"x-media": "m"
+						}}
+					},
+					"callbacks": {
+						"statusChanged": {
+							"{$request.body#/callbackUrl}": {
+								"post": {
+									"requestBody": {"content": {"application/json": {"schema": {"type": "object"}}}},
+									"responses": {"200": {"description": "OK"}}
+								}
+							},
+							"x-callback": "c"
+						},
+						"shared": {"$ref": "#/components/callbacks/Shared"}
+					},
+					"responses": {
+						"200": {
+							"description": "OK",
+							"headers": {"X-Rate": {"schema": {"type": "integer"}, "example": null, "x-header": "h"}},
+							"links": {
+								"GetOrder": {"operationId": "getOrder", "parameters": {"id": "$response.body#/id"}, "requestBody": null, "x-link": "l"},
+								"Shared": {"$ref": "#/components/links/Shared"}
+							},
+							"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Order"}}},
+							"x-response": "r"
+						},
+						"default": {"$ref": "#/components/responses/Error"}
+					}
+				}
+			}
+		},
+		"components": {
+			"securitySchemes": {"oauth": {"type": "oauth2", "flows": {"clientCredentials": {"tokenUrl": "https://example.com/token", "scopes": {}, "x-flow": 1}}}},
+			"schemas": {
+				"Order": {
+					"type": "object",
+					"additionalProperties": false,
+					"required": ["id"],
+					"properties": {
+						"id": {"type": "string", "const": "fixed"},
+						"total": {"type": "number", "exclusiveMinimum": 0},
+						"tags": {"type": "array", "items": {"type": "string"}, "prefixItems": [{"type": "string"}]},
+						"extra": {"type": "object", "additionalProperties": {"type": ["integer", "null"]}},
+						"note": {"type": ["string", "null"], "default": null, "examples": [null, "text"]}
+					},
+					"$defs": {"Id": {"type": "string"}},
+					"if": {"required": ["total"]},
+					"then": {"required": ["id"]},
+					"x-schema": "s"
+				}
+			},
+			"responses": {"Error": {"description": "Error", "x-error": true}},
+			"callbacks": {"Shared": {"{$request.query.url}": {"get": {"responses": {"200": {"description": "OK"}}}}}},
+			"links": {"Shared": {"operationRef": "#/paths/~1orders~1{id}/post"}},
+			"x-components": "c"
+		}
+	}`
+
+	data := roundTrip(t, document)
+	if !equalJSON(t, data, []byte(document)) {
+		t.Errorf("round trip changed the document:\n%s", data)
+	}
+	// A second round trip starts from the model's own output.
+	if again := roundTrip(t, string(data)); !bytes.Equal(again, data) {
+		t.Errorf("second round trip differs:\n%s\nwant\n%s", again, data)
+	}
+}
+
+func TestExplicitNulls(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		value  interface{}
+	}{
+		{"schema", `{"type": "string", "default": null, "example": null}`, &Schema{}},
+		{"parameter", `{"name": "q", "in": "query", "example": null}`, &Parameter{}},
+		{"media type", `{"example": null}`, &MediaType{}},
+		{"header", `{"example": null}`, &Header{}},
+		{"example", `{"value": null}`, &Example{}},
+		{"link", `{"operationId": "op", "requestBody": null}`, &Link{}},
+		{"absent", `{"type": "string"}`, &Schema{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := json.Unmarshal([]byte(tt.source), tt.value); err != nil {
+				t.Fatal(err)
+			}
+			data, err := json.Marshal(tt.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !equalJSON(t, data, []byte(tt.source)) {
+				t.Errorf("round trip = %s, want %s", data, tt.source)
+			}
+		})
+	}
+}
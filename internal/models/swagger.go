@@ -0,0 +1,120 @@
+package models
+
+// The types below model Swagger 2.0 documents, produced for consumers that cannot read
+// OpenAPI 3. Info, tags, external docs and schemas are shared with the OpenAPI model; schemas
+// are limited to the keywords Swagger 2.0 supports by the converter.
+
+type Swagger struct {
+	Swagger             string                           `json:"swagger"`
+	Info                Info                             `json:"info"`
+	Host                string                           `json:"host,omitempty"`
+	BasePath            string                           `json:"basePath,omitempty"`
+	Schemes             []string                         `json:"schemes,omitempty"`
+	Consumes            []string                         `json:"consumes,omitempty"`
+	Produces            []string                         `json:"produces,omitempty"`
+	Tags                []Tag                            `json:"tags,omitempty"`
+	Paths               map[string]SwaggerPathItem       `json:"paths"`
+	Definitions         map[string]*Schema               `json:"definitions,omitempty"`
+	Parameters          map[string]SwaggerParameter      `json:"parameters,omitempty"`
+	Responses           map[string]*SwaggerResponse      `json:"responses,omitempty"`
+	SecurityDefinitions map[string]SwaggerSecurityScheme `json:"securityDefinitions,omitempty"`
+	Security            []SecurityRequirement            `json:"security,omitempty"`
+	ExternalDocs        *ExternalDocs                    `json:"externalDocs,omitempty"`
+	Extensions          map[string]interface{}           `json:"-"`
+}
+
+type SwaggerPathItem struct {
+	Get        *SwaggerOperation      `json:"get,omitempty"`
+	Put        *SwaggerOperation      `json:"put,omitempty"`
+	Post       *SwaggerOperation      `json:"post,omitempty"`
+	Delete     *SwaggerOperation      `json:"delete,omitempty"`
+	Options    *SwaggerOperation      `json:"options,omitempty"`
+	Head       *SwaggerOperation      `json:"head,omitempty"`
+	Patch      *SwaggerOperation      `json:"patch,omitempty"`
+	Parameters []SwaggerParameter     `json:"parameters,omitempty"`
+	XAnyMethod bool                   `json:"x-any-method,omitempty"`
+	XWildcard  bool                   `json:"x-1c-wildcard,omitempty"`
+	Extensions map[string]interface{} `json:"-"`
+}
+
+type SwaggerOperation struct {
+	Tags         []string                    `json:"tags,omitempty"`
+	Summary      string                      `json:"summary,omitempty"`
+	Description  string                      `json:"description,omitempty"`
+	ExternalDocs *ExternalDocs               `json:"externalDocs,omitempty"`
+	OperationID  string                      `json:"operationId,omitempty"`
+	Consumes     []string                    `json:"consumes,omitempty"`
+	Produces     []string                    `json:"produces,omitempty"`
+	Parameters   []SwaggerParameter          `json:"parameters,omitempty"`
+	Responses    map[string]*SwaggerResponse `json:"responses"`
+	Schemes      []string                    `json:"schemes,omitempty"`
+	Deprecated   bool                        `json:"deprecated,omitempty"`
+	Security     []SecurityRequirement       `json:"security,omitempty"`
+	XName        string                      `json:"x-1c-name,omitempty"`
+	Extensions   map[string]interface{}      `json:"-"`
+}
+
+// SwaggerItems describes a value of a non-body parameter, a header or an array item: Swagger
+// 2.0 allows only primitive types and arrays of them there.
+type SwaggerItems struct {
+	Type             string        `json:"type,omitempty"`
+	Format           string        `json:"format,omitempty"`
+	Items            *SwaggerItems `json:"items,omitempty"`
+	CollectionFormat string        `json:"collectionFormat,omitempty"`
+	Default          interface{}   `json:"default,omitempty"`
+	Maximum          *float64      `json:"maximum,omitempty"`
+	ExclusiveMaximum bool          `json:"exclusiveMaximum,omitempty"`
+	Minimum          *float64      `json:"minimum,omitempty"`
+	ExclusiveMinimum bool          `json:"exclusiveMinimum,omitempty"`
+	MaxLength        *uint64       `json:"maxLength,omitempty"`
+	MinLength        *uint64       `json:"minLength,omitempty"`
+	Pattern          string        `json:"pattern,omitempty"`
+	MaxItems         *uint64       `json:"maxItems,omitempty"`
+	MinItems         *uint64       `json:"minItems,omitempty"`
+	UniqueItems      bool          `json:"uniqueItems,omitempty"`
+	Enum             []interface{} `json:"enum,omitempty"`
+	MultipleOf       *float64      `json:"multipleOf,omitempty"`
+}
+
+type SwaggerParameter struct {
+	Ref             string  `json:"$ref,omitempty"`
+	Name            string  `json:"name"`
+	In              string  `json:"in"`
+	Description     string  `json:"description,omitempty"`
+	Required        bool    `json:"required,omitempty"`
+	Schema          *Schema `json:"schema,omitempty"`
+	AllowEmptyValue bool    `json:"allowEmptyValue,omitempty"`
+	SwaggerItems
+	XWildcard  bool                   `json:"x-1c-wildcard,omitempty"`
+	XName      string                 `json:"x-1c-name,omitempty"`
+	XInferred  bool                   `json:"x-1c-inferred,omitempty"`
+	Extensions map[string]interface{} `json:"-"`
+}
+
+type SwaggerResponse struct {
+	Ref         string                   `json:"$ref,omitempty"`
+	Description string                   `json:"description"`
+	Schema      *Schema                  `json:"schema,omitempty"`
+	Headers     map[string]SwaggerHeader `json:"headers,omitempty"`
+	Examples    map[string]interface{}   `json:"examples,omitempty"`
+	XInferred   bool                     `json:"x-1c-inferred,omitempty"`
+	Extensions  map[string]interface{}   `json:"-"`
+}
+
+type SwaggerHeader struct {
+	Description string `json:"description,omitempty"`
+	SwaggerItems
+	Extensions map[string]interface{} `json:"-"`
+}
+
+type SwaggerSecurityScheme struct {
+	Type             string                 `json:"type"`
+	Description      string                 `json:"description,omitempty"`
+	Name             string                 `json:"name,omitempty"`
+	In               string                 `json:"in,omitempty"`
+	Flow             string                 `json:"flow,omitempty"`
+	AuthorizationURL string                 `json:"authorizationUrl,omitempty"`
+	TokenURL         string                 `json:"tokenUrl,omitempty"`
+	Scopes           map[string]string      `json:"scopes,omitempty"`
+	Extensions       map[string]interface{} `json:"-"`
+}
@@ -0,0 +1,116 @@
+package reader
+
+import (
+	"bytes"
+	"encoding/xml"
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+// Configuration holds the properties of a configuration or an extension that describe the API:
+// the root object of Configuration.xml in a Designer dump.
+type Configuration struct {
+	XMLName    xml.Name                `xml:"Configuration"`
+	UUID       string                  `xml:"uuid,attr"`
+	Properties ConfigurationProperties `xml:"Properties"`
+	// Source is the name of the extension directory; empty for the base configuration.
+	Source string `xml:"-"`
+}
+
+type ConfigurationProperties struct {
+	Name                     string      `xml:"Name"`
+	Synonym                  LocalString `xml:"Synonym"`
+	BriefInformation         LocalString `xml:"BriefInformation"`
+	DetailedInformation      LocalString `xml:"DetailedInformation"`
+	Copyright                LocalString `xml:"Copyright"`
+	VendorInformationAddress LocalString `xml:"VendorInformationAddress"`
+	Vendor                   string      `xml:"Vendor"`
+	Version                  string      `xml:"Version"`
+}
+
+// configurationMetaDataObject is the root element of Configuration.xml.
+type configurationMetaDataObject struct {
+	XMLName       xml.Name      `xml:"MetaDataObject"`
+	Configuration Configuration `xml:"Configuration"`
+}
+
+// EDTConfiguration is the configuration of a 1C:EDT project (src/Configuration/Configuration.mdo).
+type EDTConfiguration struct {
+	XMLName                  xml.Name         `xml:"Configuration"`
+	UUID                     string           `xml:"uuid,attr"`
+	Name                     string           `xml:"name"`
+	Synonym                  []EDTLocalString `xml:"synonym"`
+	BriefInformation         []EDTLocalString `xml:"briefInformation"`
+	DetailedInformation      []EDTLocalString `xml:"detailedInformation"`
+	Copyright                []EDTLocalString `xml:"copyright"`
+	VendorInformationAddress []EDTLocalString `xml:"vendorInformationAddress"`
+	Vendor                   string           `xml:"vendor"`
+	Version                  string           `xml:"version"`
+}
+
+// ConfigurationPath returns the file with the configuration properties for the given source format.
+func ConfigurationPath(root, format string) string {
+	if format == FormatEDT {
+		return filepath.Join(root, "src", "Configuration", "Configuration.mdo")
+	}
+	return filepath.Join(root, "Configuration.xml")
+}
+
+// ReadProjectConfiguration detects the format of a configuration or an extension and reads its
+// properties. A project without a configuration file yields nil.
+func ReadProjectConfiguration(root string, log *slog.Logger) (*Configuration, error) {
+	format := DetectFormat(root)
+	path := ConfigurationPath(root, format)
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		log.Debug("No configuration file", "path", path)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Check for UTF-8 BOM
+	content = bytes.TrimPrefix(content, utf8BOM)
+
+	if format == FormatEDT {
+		var data EDTConfiguration
+		if err := xml.Unmarshal(content, &data); err != nil {
+			return nil, err
+		}
+		configuration := data.toConfiguration()
+		log.Info("Successfully parsed configuration", "path", path, "name", configuration.Properties.Name, "version", configuration.Properties.Version)
+		return &configuration, nil
+	}
+
+	var data configurationMetaDataObject
+	if err := xml.Unmarshal(content, &data); err != nil {
+		return nil, err
+	}
+	log.Info("Successfully parsed configuration", "path", path, "name", data.Configuration.Properties.Name, "version", data.Configuration.Properties.Version)
+	return &data.Configuration, nil
+}
+
+// toConfiguration converts the EDT representation to the model shared with the Designer reader.
+func (c EDTConfiguration) toConfiguration() Configuration {
+	configuration := Configuration{UUID: c.UUID}
+	configuration.Properties = ConfigurationProperties{
+		Name:                     c.Name,
+		Synonym:                  edtLocalString(c.Synonym),
+		BriefInformation:         edtLocalString(c.BriefInformation),
+		DetailedInformation:      edtLocalString(c.DetailedInformation),
+		Copyright:                edtLocalString(c.Copyright),
+		VendorInformationAddress: edtLocalString(c.VendorInformationAddress),
+		Vendor:                   c.Vendor,
+		Version:                  c.Version,
+	}
+	return configuration
+}
+
+// ConfigurationInfo holds the properties of the base configuration and of the extensions
+// applied to it. Configuration is nil when the sources have no configuration file.
+type ConfigurationInfo struct {
+	Configuration *Configuration
+	Extensions    []Configuration
+}
@@ -0,0 +1,133 @@
+package reader
+
+import (
+	"bytes"
+	"encoding/xml"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// EDTHTTPService is an HTTP service of a 1C:EDT project (src/HTTPServices/<Name>/<Name>.mdo).
+type EDTHTTPService struct {
+	XMLName      xml.Name         `xml:"HTTPService"`
+	UUID         string           `xml:"uuid,attr"`
+	Name         string           `xml:"name"`
+	Synonym      []EDTLocalString `xml:"synonym"`
+	Comment      string           `xml:"comment"`
+	RootURL      string           `xml:"rootURL"`
+	URLTemplates []EDTURLTemplate `xml:"urlTemplates"`
+}
+
+type EDTURLTemplate struct {
+	UUID     string           `xml:"uuid,attr"`
+	Name     string           `xml:"name"`
+	Synonym  []EDTLocalString `xml:"synonym"`
+	Comment  string           `xml:"comment"`
+	Template string           `xml:"template"`
+	Methods  []EDTMethod      `xml:"methods"`
+}
+
+type EDTMethod struct {
+	UUID       string           `xml:"uuid,attr"`
+	Name       string           `xml:"name"`
+	Synonym    []EDTLocalString `xml:"synonym"`
+	Comment    string           `xml:"comment"`
+	HTTPMethod string           `xml:"httpMethod"`
+	Handler    string           `xml:"handler"`
+}
+
+// EDTLocalString is one language entry of a multilingual EDT property.
+type EDTLocalString struct {
+	Key   string `xml:"key"`
+	Value string `xml:"value"`
+}
+
+// edtDefaultHTTPMethod is the HTTP method of an EDT method without an explicit httpMethod:
+// EDT does not serialise properties that hold their default value.
+const edtDefaultHTTPMethod = "GET"
+
+// ReadEDTHTTPServices reads HTTP services from the src/HTTPServices directory of a 1C:EDT project.
+func ReadEDTHTTPServices(path string, log *slog.Logger) ([]HTTPService, error) {
+	log.Info("Reading EDT http services", "path", path)
+	var services []HTTPService
+	err := filepath.Walk(path, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			log.Error("Error accessing path", "path", path, "error", err)
+			return err
+		}
+		if !info.IsDir() && strings.HasSuffix(info.Name(), ".mdo") {
+			log.Info("Found http service file", "path", path)
+			content, err := os.ReadFile(path)
+			if err != nil {
+				log.Error("Error reading file", "path", path, "error", err)
+				return err
+			}
+
+			// Check for UTF-8 BOM
+			if bytes.HasPrefix(content, utf8BOM) {
+				content = bytes.TrimPrefix(content, utf8BOM)
+			}
+
+			var data EDTHTTPService
+			if err := xml.Unmarshal(content, &data); err != nil {
+				log.Error("Error decoding mdo", "path", path, "error", err)
+				return err
+			}
+
+			log.Info("Successfully parsed http service", "path", path, "service", data.Name)
+			log.Debug("Parsed data", "data", data)
+			service := data.toHTTPService()
+			service.setModulePath(filepath.Join(filepath.Dir(path), "Module.bsl"))
+			services = append(services, service)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error("Error walking through http services directory", "path", path, "error", err)
+		return nil, err
+	}
+	return services, nil
+}
+
+// toHTTPService converts the EDT representation to the model shared with the Designer reader.
+func (s EDTHTTPService) toHTTPService() HTTPService {
+	service := HTTPService{UUID: s.UUID}
+	service.Properties.Name = s.Name
+	service.Properties.Synonym = edtLocalString(s.Synonym)
+	service.Properties.Comment = s.Comment
+	service.Properties.RootURL = s.RootURL
+
+	for _, t := range s.URLTemplates {
+		template := URLTemplate{UUID: t.UUID}
+		template.Properties.Name = t.Name
+		template.Properties.Synonym = edtLocalString(t.Synonym)
+		template.Properties.Comment = t.Comment
+		template.Properties.Template = t.Template
+
+		for _, m := range t.Methods {
+			method := Method{UUID: m.UUID}
+			method.Properties.Name = m.Name
+			method.Properties.Synonym = edtLocalString(m.Synonym)
+			method.Properties.Comment = m.Comment
+			method.Properties.HTTPMethod = m.HTTPMethod
+			if method.Properties.HTTPMethod == "" {
+				method.Properties.HTTPMethod = edtDefaultHTTPMethod
+			}
+			method.Properties.Handler = m.Handler
+			template.Methods = append(template.Methods, method)
+		}
+		service.URLTemplates = append(service.URLTemplates, template)
+	}
+
+	return service
+}
+
+func edtLocalString(entries []EDTLocalString) LocalString {
+	var s LocalString
+	for _, entry := range entries {
+		s.Items = append(s.Items, LocalStringItem{Lang: entry.Key, Content: entry.Value})
+	}
+	return s
+}
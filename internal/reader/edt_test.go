@@ -0,0 +1,112 @@
+package reader
+
+import (
+	"encoding/xml"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// The EDT example project holds the Биллинг service and the configuration properties of the
+// Designer example dump, so both readers must produce the same model.
+const (
+	designerExample = "../../docs/example/cf"
+	edtExample      = "../../docs/example/edt"
+)
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		root string
+		want string
+	}{
+		{designerExample, FormatDesigner},
+		{edtExample, FormatEDT},
+		{t.TempDir(), FormatDesigner},
+	}
+	for _, tt := range tests {
+		if got := DetectFormat(tt.root); got != tt.want {
+			t.Errorf("DetectFormat(%q) = %q, want %q", tt.root, got, tt.want)
+		}
+	}
+}
+
+func TestReadEDTHTTPServicesMatchesDesigner(t *testing.T) {
+	designer, err := ReadProjectHTTPServices(designerExample, discardLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
+	edt, err := ReadProjectHTTPServices(edtExample, discardLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(edt) != 1 {
+		t.Fatalf("read %d EDT services, want 1", len(edt))
+	}
+
+	var want *HTTPService
+	for i := range designer {
+		if designer[i].Properties.Name == edt[0].Properties.Name {
+			want = &designer[i]
+		}
+	}
+	if want == nil {
+		t.Fatalf("service %q is not in the Designer example", edt[0].Properties.Name)
+	}
+
+	got := edt[0]
+	if module := filepath.Join(edtExample, "src", "HTTPServices", "Биллинг", "Module.bsl"); got.ModulePath != module {
+		t.Errorf("ModulePath = %q, want %q", got.ModulePath, module)
+	}
+	for _, template := range got.URLTemplates {
+		for _, method := range template.Methods {
+			if method.ModulePath != got.ModulePath {
+				t.Errorf("method %s ModulePath = %q, want %q", method.Properties.Name, method.ModulePath, got.ModulePath)
+			}
+		}
+	}
+
+	normalizeService(want)
+	normalizeService(&got)
+	if !reflect.DeepEqual(got, *want) {
+		t.Errorf("EDT service differs from the Designer one:\n got %+v\nwant %+v", got, *want)
+	}
+}
+
+func TestReadEDTConfigurationMatchesDesigner(t *testing.T) {
+	designer, err := ReadProjectConfiguration(designerExample, discardLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
+	edt, err := ReadProjectConfiguration(edtExample, discardLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if designer == nil || edt == nil {
+		t.Fatalf("configuration not read: designer %v, EDT %v", designer, edt)
+	}
+	designer.XMLName = xml.Name{}
+	if !reflect.DeepEqual(edt, designer) {
+		t.Errorf("EDT configuration differs from the Designer one:\n got %+v\nwant %+v", edt, designer)
+	}
+}
+
+// normalizeService clears what legitimately differs between the formats: the XML element
+// names and the module paths.
+func normalizeService(service *HTTPService) {
+	service.XMLName = xml.Name{}
+	service.ModulePath = ""
+	for i := range service.URLTemplates {
+		template := &service.URLTemplates[i]
+		template.XMLName = xml.Name{}
+		for j := range template.Methods {
+			template.Methods[j].XMLName = xml.Name{}
+			template.Methods[j].ModulePath = ""
+		}
+	}
+}
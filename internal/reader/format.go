@@ -0,0 +1,55 @@
+package reader
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+// Source formats of a configuration or an extension.
+const (
+	// FormatDesigner is the Designer "dump to files" layout: HTTPServices/<Name>.xml.
+	FormatDesigner = "designer"
+	// FormatEDT is the 1C:EDT project layout: src/HTTPServices/<Name>/<Name>.mdo.
+	FormatEDT = "edt"
+)
+
+// DetectFormat determines whether the directory holds a Designer dump or a 1C:EDT project.
+func DetectFormat(root string) string {
+	edtMarkers := []string{
+		filepath.Join(root, "DT-INF"),
+		filepath.Join(root, "src", "Configuration", "Configuration.mdo"),
+		filepath.Join(root, "src", "HTTPServices"),
+	}
+	for _, marker := range edtMarkers {
+		if _, err := os.Stat(marker); err == nil {
+			return FormatEDT
+		}
+	}
+	return FormatDesigner
+}
+
+// HTTPServicesPath returns the directory with HTTP services for the given source format.
+func HTTPServicesPath(root, format string) string {
+	if format == FormatEDT {
+		return filepath.Join(root, "src", "HTTPServices")
+	}
+	return filepath.Join(root, "HTTPServices")
+}
+
+// ReadProjectHTTPServices detects the format of a configuration or an extension and reads its
+// HTTP services. A project without an HTTP services directory yields no services.
+func ReadProjectHTTPServices(root string, log *slog.Logger) ([]HTTPService, error) {
+	format := DetectFormat(root)
+	httpServicesPath := HTTPServicesPath(root, format)
+	if _, err := os.Stat(httpServicesPath); os.IsNotExist(err) {
+		log.Debug("No http services directory", "path", httpServicesPath)
+		return nil, nil
+	}
+
+	log.Info("Detected project format", "path", root, "format", format)
+	if format == FormatEDT {
+		return ReadEDTHTTPServices(httpServicesPath, log)
+	}
+	return ReadHTTPServices(httpServicesPath, log)
+}
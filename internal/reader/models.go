@@ -3,6 +3,7 @@ package reader
 import (
 	"encoding/xml"
 	"one_c_swagger/internal/models"
+	"strings"
 )
 
 type MetaDataObject struct {
@@ -15,17 +16,40 @@ type HTTPService struct {
 	UUID         string                `xml:"uuid,attr"`
 	Properties   HTTPServiceProperties `xml:"Properties"`
 	URLTemplates []URLTemplate         `xml:"ChildObjects>URLTemplate"`
+	// ModulePath is the path of the BSL module with the handlers of the service.
+	ModulePath string `xml:"-"`
+	// Source is the name of the extension the service comes from; empty for the base configuration.
+	Source string `xml:"-"`
+}
+
+// SetSource records the extension the service comes from on the service and on each of its
+// templates and methods, so that merged objects keep their origin.
+func (s *HTTPService) SetSource(source string) {
+	s.Source = source
+	for i := range s.URLTemplates {
+		s.URLTemplates[i].Source = source
+		for j := range s.URLTemplates[i].Methods {
+			s.URLTemplates[i].Methods[j].Source = source
+		}
+	}
+}
+
+// setModulePath records the module path on the service and on each of its methods, so that
+// methods keep pointing to their own module after extensions are merged in.
+func (s *HTTPService) setModulePath(path string) {
+	s.ModulePath = path
+	for i := range s.URLTemplates {
+		for j := range s.URLTemplates[i].Methods {
+			s.URLTemplates[i].Methods[j].ModulePath = path
+		}
+	}
 }
 
 type HTTPServiceProperties struct {
-	Name    string `xml:"Name"`
-	Synonym struct {
-		Item struct {
-			Lang    string `xml:"lang"`
-			Content string `xml:"content"`
-		} `xml:"item"`
-	} `xml:"Synonym"`
-	RootURL string `xml:"RootURL"`
+	Name    string      `xml:"Name"`
+	Synonym LocalString `xml:"Synonym"`
+	Comment string      `xml:"Comment"`
+	RootURL string      `xml:"RootURL"`
 }
 
 type URLTemplate struct {
@@ -33,30 +57,61 @@ type URLTemplate struct {
 	UUID       string                `xml:"uuid,attr"`
 	Properties URLTemplateProperties `xml:"Properties"`
 	Methods    []Method              `xml:"ChildObjects>Method"`
+	// Source is the name of the extension the template comes from; empty for the base configuration.
+	Source string `xml:"-"`
 }
 
 type URLTemplateProperties struct {
-	Name    string `xml:"Name"`
-	Synonym struct {
-		Item struct {
-			Lang    string `xml:"lang"`
-			Content string `xml:"content"`
-		} `xml:"item"`
-	} `xml:"Synonym"`
-	Template string `xml:"Template"`
+	Name     string      `xml:"Name"`
+	Synonym  LocalString `xml:"Synonym"`
+	Comment  string      `xml:"Comment"`
+	Template string      `xml:"Template"`
 }
 
 type Method struct {
 	XMLName    xml.Name         `xml:"Method"`
 	UUID       string           `xml:"uuid,attr"`
 	Properties MethodProperties `xml:"Properties"`
+	// ModulePath is the path of the BSL module that contains the handler.
+	ModulePath string `xml:"-"`
+	// Source is the name of the extension the method comes from; empty for the base configuration.
+	Source string `xml:"-"`
 }
 
 type MethodProperties struct {
-	Name       string `xml:"Name"`
-	Synonym    string `xml:"Synonym"`
-	HTTPMethod string `xml:"HTTPMethod"`
-	Handler    string `xml:"Handler"`
+	Name       string      `xml:"Name"`
+	Synonym    LocalString `xml:"Synonym"`
+	Comment    string      `xml:"Comment"`
+	HTTPMethod string      `xml:"HTTPMethod"`
+	Handler    string      `xml:"Handler"`
+}
+
+// LocalString is a multilingual 1C property such as Synonym: one v8:item per language.
+type LocalString struct {
+	Items []LocalStringItem `xml:"item"`
+}
+
+type LocalStringItem struct {
+	Lang    string `xml:"lang"`
+	Content string `xml:"content"`
+}
+
+// Text returns the entry for the first language of the chain that has a non-empty value.
+// When none of the languages is present, the first non-empty entry is returned.
+func (s LocalString) Text(languages ...string) string {
+	for _, lang := range languages {
+		for _, item := range s.Items {
+			if strings.EqualFold(item.Lang, lang) && item.Content != "" {
+				return item.Content
+			}
+		}
+	}
+	for _, item := range s.Items {
+		if item.Content != "" {
+			return item.Content
+		}
+	}
+	return ""
 }
 
 type SwaggerConfig struct {
@@ -67,6 +122,13 @@ type SwaggerConfig struct {
 }
 
 type AllServicesConfig struct {
-	Servers    []models.Server   `json:"servers,omitempty"`
-	Components models.Components `json:"components,omitempty"`
+	// Info overrides the fields of the info object taken from the configuration properties.
+	Info    *models.Info    `json:"info,omitempty"`
+	Servers []models.Server `json:"servers,omitempty"`
+	// DefaultResponses maps status codes to the responses attached to every operation.
+	// Values are names of components.responses or references to them.
+	DefaultResponses map[string]string `json:"defaultResponses,omitempty"`
+	Components       models.Components `json:"components,omitempty"`
+	// Webhooks are copied to OpenAPI 3.1 documents; OpenAPI 3.0 has no webhooks.
+	Webhooks map[string]models.PathItem `json:"webhooks,omitempty"`
 }
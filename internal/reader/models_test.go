@@ -0,0 +1,40 @@
+package reader
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+func TestLocalStringText(t *testing.T) {
+	const synonym = `<Synonym xmlns:v8="http://v8.1c.ru/8.1/data/core">
+		<v8:item><v8:lang>en</v8:lang><v8:content></v8:content></v8:item>
+		<v8:item><v8:lang>ru</v8:lang><v8:content>Счет на оплату</v8:content></v8:item>
+		<v8:item><v8:lang>EN</v8:lang><v8:content>Invoice</v8:content></v8:item>
+		<v8:item><v8:lang>kk</v8:lang><v8:content>Төлем шоты</v8:content></v8:item>
+	</Synonym>`
+	var s LocalString
+	if err := xml.Unmarshal([]byte(synonym), &s); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		languages []string
+		want      string
+	}{
+		{"first language of the chain", []string{"kk", "ru"}, "Төлем шоты"},
+		{"empty content skipped", []string{"en"}, "Invoice"},
+		{"missing language skipped", []string{"de", "ru"}, "Счет на оплату"},
+		{"no languages", nil, "Счет на оплату"},
+		{"fallback to the first non-empty item", []string{"de"}, "Счет на оплату"},
+	}
+	for _, tt := range tests {
+		if got := s.Text(tt.languages...); got != tt.want {
+			t.Errorf("%s: Text(%q) = %q, want %q", tt.name, tt.languages, got, tt.want)
+		}
+	}
+
+	if got := (LocalString{}).Text("ru"); got != "" {
+		t.Errorf("Text of an empty synonym = %q, want empty", got)
+	}
+}
@@ -43,6 +43,7 @@ func ReadHTTPServices(path string, log *slog.Logger) ([]HTTPService, error) {
 
 			log.Info("Successfully parsed http service", "path", path, "service", data.HTTPService.Properties.Name)
 			log.Debug("Parsed data", "data", data)
+			data.HTTPService.setModulePath(filepath.Join(strings.TrimSuffix(path, ".xml"), "Ext", "Module.bsl"))
 			services = append(services, data.HTTPService)
 
 		}
@@ -75,6 +76,25 @@ func ReadSwaggerConfigFile(swaggerConfigPath, serviceName string, log *slog.Logg
 	return &config, nil
 }
 
+// ListSwaggerConfigFiles returns the service names of all overlay files in the directory,
+// that is the names of the .json files except the all services config file.
+func ListSwaggerConfigFiles(swaggerConfigPath, allServicesFileName string) ([]string, error) {
+	entries, err := os.ReadDir(swaggerConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".json" || name == allServicesFileName {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(name, filepath.Ext(name)))
+	}
+	return names, nil
+}
+
 func ReadAllServicesConfigFile(swaggerConfigPath, fileName string, log *slog.Logger) (*AllServicesConfig, error) {
 	configPath := filepath.Join(swaggerConfigPath, fileName)
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
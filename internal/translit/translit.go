@@ -0,0 +1,124 @@
+package translit
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Scheme names accepted by Lookup.
+const (
+	Simple = "simple"
+	GOST   = "gost"
+	// ISO9 is another name of GOST: GOST 7.79-2000 system B, which renders ISO 9 in plain
+	// ASCII. System A of ISO 9, one Latin letter with diacritics per Cyrillic letter, is not
+	// supported, since its letters are not valid in identifiers either.
+	ISO9 = "iso9"
+)
+
+// Scheme transliterates Cyrillic text into Latin.
+type Scheme interface {
+	Transliterate(str string) string
+}
+
+// Table maps Cyrillic letters to their Latin replacements.
+// Only lower-case letters are listed; upper-case letters are derived from them.
+type Table map[rune]string
+
+// SimpleScheme is a plain Latin transliteration that produces ASCII-only identifiers.
+var SimpleScheme = Table{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya",
+}
+
+// GOSTScheme is GOST 7.79-2000 system B, the ASCII-only variant of ISO 9.
+// The letter `ц` is written as `c` before `е`, `и`, `ы`, `й` and as `cz` elsewhere.
+var GOSTScheme = gostScheme{}
+
+var gostTable = Table{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "j", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "x", 'ц': "cz",
+	'ч': "ch", 'ш': "sh", 'щ': "shh", 'ъ': "``", 'ы': "y'", 'ь': "`", 'э': "e`", 'ю': "yu",
+	'я': "ya",
+}
+
+// Lookup returns the scheme registered under the given name.
+func Lookup(name string) (Scheme, error) {
+	switch strings.ToLower(name) {
+	case Simple:
+		return SimpleScheme, nil
+	case GOST, ISO9:
+		return GOSTScheme, nil
+	}
+	return nil, fmt.Errorf("unknown transliteration scheme %q", name)
+}
+
+// Transliterate replaces every letter known to the table with its Latin counterpart.
+func (t Table) Transliterate(str string) string {
+	var b strings.Builder
+	for _, r := range str {
+		t.write(&b, r, t[unicode.ToLower(r)])
+	}
+	return b.String()
+}
+
+// write appends the replacement of r, or r itself when the table does not know it.
+// The case of the first letter of a replacement follows the case of the source letter.
+func (t Table) write(b *strings.Builder, r rune, latin string) {
+	if _, ok := t[unicode.ToLower(r)]; !ok {
+		b.WriteRune(r)
+		return
+	}
+	if unicode.IsUpper(r) && latin != "" {
+		first := []rune(latin)
+		b.WriteString(strings.ToUpper(string(first[0])))
+		b.WriteString(string(first[1:]))
+		return
+	}
+	b.WriteString(latin)
+}
+
+type gostScheme struct{}
+
+func (gostScheme) Transliterate(str string) string {
+	runes := []rune(str)
+	var b strings.Builder
+	for i, r := range runes {
+		latin := gostTable[unicode.ToLower(r)]
+		if unicode.ToLower(r) == 'ц' && i+1 < len(runes) {
+			switch unicode.ToLower(runes[i+1]) {
+			case 'е', 'и', 'ы', 'й':
+				latin = "c"
+			}
+		}
+		gostTable.write(&b, r, latin)
+	}
+	return b.String()
+}
+
+// Identifier transliterates name with the scheme and drops every character that is not an
+// ASCII letter, digit or underscore, so the result is usable by client code generators.
+// A non-empty name always yields a non-empty identifier: when nothing is left, for instance
+// for a name in a script the scheme does not know, the code points are spelled out (u2605).
+func Identifier(scheme Scheme, name string) string {
+	latin := scheme.Transliterate(name)
+	var b strings.Builder
+	for _, r := range latin {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_') {
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() == 0 {
+		for i, r := range name {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			fmt.Fprintf(&b, "u%04x", r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,71 @@
+package translit
+
+import "testing"
+
+func TestLookup(t *testing.T) {
+	// The schemes are told apart by the way they write "Улица".
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{name: "simple", want: "Ulitsa"},
+		{name: "GOST", want: "Ulicza"},
+		{name: "iso9", want: "Ulicza"},
+		{name: "iso9a", wantErr: true},
+	}
+	for _, tt := range tests {
+		scheme, err := Lookup(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Lookup(%q) error = %v, want error %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && scheme.Transliterate("Улица") != tt.want {
+			t.Errorf("Lookup(%q) transliterates %q as %q, want %q", tt.name, "Улица", scheme.Transliterate("Улица"), tt.want)
+		}
+	}
+}
+
+func TestTransliterate(t *testing.T) {
+	tests := []struct {
+		scheme Scheme
+		in     string
+		want   string
+	}{
+		{SimpleScheme, "ПередачаДанных", "PeredachaDannykh"},
+		{SimpleScheme, "Щука и Ёж", "Shchuka i Ezh"},
+		{SimpleScheme, "Объект_1", "Obekt_1"},
+		{GOSTScheme, "ПередачаДанных", "PeredachaDanny'x"},
+		{GOSTScheme, "Цена", "Cena"},
+		{GOSTScheme, "Улица", "Ulicza"},
+		{GOSTScheme, "Объём", "Ob``yom"},
+		{GOSTScheme, "Version", "Version"},
+	}
+	for _, tt := range tests {
+		if got := tt.scheme.Transliterate(tt.in); got != tt.want {
+			t.Errorf("Transliterate(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	tests := []struct {
+		scheme Scheme
+		in     string
+		want   string
+	}{
+		{GOSTScheme, "Объём", "Obyom"},
+		{GOSTScheme, "Сыр", "Syr"},
+		{SimpleScheme, "Счет-фактура 2", "Schetfaktura2"},
+		{SimpleScheme, "Ид_Заказа", "Id_Zakaza"},
+		{SimpleScheme, "", ""},
+		{SimpleScheme, "★", "u2605"},
+		{SimpleScheme, "名前", "u540d_u524d"},
+		{SimpleScheme, "Ъ", "u042a"},
+	}
+	for _, tt := range tests {
+		if got := Identifier(tt.scheme, tt.in); got != tt.want {
+			t.Errorf("Identifier(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
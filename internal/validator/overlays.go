@@ -0,0 +1,341 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"one_c_swagger/internal/reader"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// overlayMethods are the operations the generator takes from an overlay path item.
+var overlayMethods = []string{"get", "post", "put", "delete", "head", "patch", "merge", "options", "trace", "connect", "propfind", "proppatch", "move", "copy", "lock", "unlock", "mkcol"}
+
+// schemaNode identifies a schema for the reachability check: file is empty for global schemas.
+type schemaNode struct {
+	file string
+	name string
+}
+
+// overlayChecker accumulates the issues of all overlay files and the schema reference graph.
+type overlayChecker struct {
+	issues []Issue
+	// global holds the component names of the all services config by section.
+	global map[string]map[string]bool
+	// edges holds the schemas referenced from each schema; roots the schemas referenced from
+	// anywhere else.
+	edges map[schemaNode][]schemaNode
+	roots []schemaNode
+	// schemas lists the schemas of all overlays.
+	schemas []schemaNode
+}
+
+// ValidateOverlays cross-checks the overlay files against the HTTP services. Overlays are
+// keyed by service name, which is also the name of their file. It reports overlays of unknown
+// services, path keys that are not URL templates of the service, operations for methods the
+// template does not have, references that will not resolve in the generated document and
+// schemas that nothing refers to.
+func ValidateOverlays(services []reader.HTTPService, overlays map[string]*reader.SwaggerConfig, allServices *reader.AllServicesConfig, allServicesFileName string) []Issue {
+	c := &overlayChecker{
+		global: make(map[string]map[string]bool),
+		edges:  make(map[schemaNode][]schemaNode),
+	}
+
+	var allServicesTree map[string]interface{}
+	if allServices != nil {
+		allServicesTree = jsonTree(allServices)
+		components, _ := allServicesTree["components"].(map[string]interface{})
+		for section, entries := range components {
+			names := make(map[string]bool)
+			for name := range asObject(entries) {
+				names[name] = true
+			}
+			c.global[section] = names
+		}
+		c.checkRefs(allServicesFileName, "", allServicesTree, nil, nil)
+		c.checkDefaultResponses(allServicesFileName, allServices.DefaultResponses)
+	}
+
+	servicesByName := make(map[string]reader.HTTPService)
+	for _, service := range services {
+		servicesByName[service.Properties.Name] = service
+	}
+
+	names := make([]string, 0, len(overlays))
+	for name := range overlays {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		overlay := overlays[name]
+		file := name + ".json"
+
+		localSchemas := make(map[string]bool)
+		for schemaName := range overlay.Components.Schemas {
+			localSchemas[schemaName] = true
+			c.schemas = append(c.schemas, schemaNode{file: file, name: schemaName})
+		}
+
+		service, ok := servicesByName[name]
+		if ok {
+			c.checkPaths(file, service, overlay)
+		} else {
+			c.errorf(file, "", "no HTTP service named %q%s", name, suggestion(name, serviceNames(services), similarName))
+		}
+
+		c.checkRefs(file, "", jsonTree(overlay), localSchemas, nil)
+	}
+
+	c.checkReachability(allServicesFileName)
+
+	sort.SliceStable(c.issues, func(i, j int) bool {
+		if c.issues[i].File != c.issues[j].File {
+			return c.issues[i].File < c.issues[j].File
+		}
+		return c.issues[i].Path < c.issues[j].Path
+	})
+	return c.issues
+}
+
+func (c *overlayChecker) errorf(file, path, format string, args ...interface{}) {
+	c.issues = append(c.issues, Issue{Severity: SeverityError, File: file, Path: pathOrRoot(path), Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *overlayChecker) warnf(file, path, format string, args ...interface{}) {
+	c.issues = append(c.issues, Issue{Severity: SeverityWarning, File: file, Path: pathOrRoot(path), Message: fmt.Sprintf(format, args...)})
+}
+
+// checkDefaultResponses checks that default responses are keyed by status codes and refer to
+// global responses.
+func (c *overlayChecker) checkDefaultResponses(file string, defaultResponses map[string]string) {
+	codes := make([]string, 0, len(defaultResponses))
+	for code := range defaultResponses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		path := pointer("/defaultResponses", code)
+		if code != "default" && !responseCodeRe.MatchString(code) {
+			c.errorf(file, path, "key %q is not a status code or default", code)
+		}
+		name := strings.TrimPrefix(defaultResponses[code], "#/components/responses/")
+		if !c.global["responses"][name] {
+			c.errorf(file, path, "response %q is not declared in components.responses", defaultResponses[code])
+		}
+	}
+}
+
+// checkPaths matches the path keys of an overlay with the URL templates of the service and
+// the operations with the template methods, the way the generator looks them up.
+func (c *overlayChecker) checkPaths(file string, service reader.HTTPService, overlay *reader.SwaggerConfig) {
+	templates := make(map[string]reader.URLTemplate)
+	var templateKeys []string
+	for _, urlTemplate := range service.URLTemplates {
+		templates[urlTemplate.Properties.Template] = urlTemplate
+		templateKeys = append(templateKeys, urlTemplate.Properties.Template)
+	}
+
+	for _, key := range sortedKeys(overlay.Paths) {
+		path := pointer("/paths", key)
+		urlTemplate, ok := templates[key]
+		if !ok {
+			c.errorf(file, path, "service %s has no URL template %q%s", service.Properties.Name, key, suggestion(key, templateKeys, sameTemplate))
+			continue
+		}
+		item, ok := overlay.Paths[key].(map[string]interface{})
+		if !ok {
+			c.errorf(file, path, "must be an object")
+			continue
+		}
+
+		methods := make(map[string]bool)
+		for _, method := range urlTemplate.Methods {
+			methods[strings.ToLower(method.Properties.HTTPMethod)] = true
+		}
+		for _, field := range sortedKeys(item) {
+			method := strings.ToLower(field)
+			switch {
+			case isExtension(field):
+			case contains(overlayMethods, method):
+				if !methods[method] {
+					c.errorf(file, pointer(path, field), "URL template %q has no %s method; the operation is ignored", key, strings.ToUpper(method))
+				}
+			case field == "$ref":
+				c.warnf(file, pointer(path, field), "path item references are not supported and are ignored")
+			case contains(pathItemFields, field):
+			default:
+				c.errorf(file, pointer(path, field), "unknown HTTP method %q", field)
+			}
+		}
+	}
+}
+
+// checkRefs reports component references that will not resolve in the generated document and
+// records schema references for the reachability check. Overlay schemas are exported with the
+// service prefix and shadow global ones; other overlay components are not exported, so
+// references to them must resolve in the all services config. from is the schema the value
+// belongs to, if any.
+func (c *overlayChecker) checkRefs(file, path string, value interface{}, localSchemas map[string]bool, from *schemaNode) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for _, key := range sortedKeys(value) {
+			ref, isString := value[key].(string)
+			if key != "$ref" || !isString {
+				childFrom := from
+				if from == nil && path == "/components/schemas" {
+					childFrom = &schemaNode{name: key}
+					if localSchemas != nil {
+						childFrom.file = file
+					}
+				}
+				c.checkRefs(file, pointer(path, key), value[key], localSchemas, childFrom)
+				continue
+			}
+			if !strings.HasPrefix(ref, "#") {
+				continue
+			}
+			c.resolveRef(file, pointer(path, key), ref, localSchemas, from)
+		}
+	case []interface{}:
+		for i, item := range value {
+			c.checkRefs(file, pointer(path, strconv.Itoa(i)), item, localSchemas, from)
+		}
+	}
+}
+
+func (c *overlayChecker) resolveRef(file, path, ref string, localSchemas map[string]bool, from *schemaNode) {
+	parts := strings.Split(strings.TrimPrefix(ref, "#/"), "/")
+	if len(parts) != 3 || parts[0] != "components" {
+		c.errorf(file, path, "reference %q does not point to a component", ref)
+		return
+	}
+	section, name := parts[1], strings.ReplaceAll(strings.ReplaceAll(parts[2], "~1", "/"), "~0", "~")
+
+	var target schemaNode
+	switch {
+	case section == "schemas" && localSchemas[name]:
+		target = schemaNode{file: file, name: name}
+	case c.global[section][name]:
+		target = schemaNode{name: name}
+	default:
+		c.errorf(file, path, "reference %q does not resolve%s", ref, suggestion(name, sortedNames(c.global[section], localSchemas, section), similarName))
+		return
+	}
+
+	if section != "schemas" {
+		return
+	}
+	if from != nil {
+		c.edges[*from] = append(c.edges[*from], target)
+	} else {
+		c.roots = append(c.roots, target)
+	}
+}
+
+// checkReachability reports schemas that cannot be reached from paths and non-schema
+// components. Handler doc comments may refer to schemas too, so these are warnings.
+func (c *overlayChecker) checkReachability(allServicesFileName string) {
+	reached := make(map[schemaNode]bool)
+	queue := append([]schemaNode(nil), c.roots...)
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if reached[node] {
+			continue
+		}
+		reached[node] = true
+		queue = append(queue, c.edges[node]...)
+	}
+
+	for name := range c.global["schemas"] {
+		if !reached[schemaNode{name: name}] {
+			c.warnf(allServicesFileName, pointer("/components/schemas", name), "schema %q is not referenced", name)
+		}
+	}
+	for _, node := range c.schemas {
+		if !reached[node] {
+			c.warnf(node.file, pointer("/components/schemas", node.name), "schema %q is not referenced", node.name)
+		}
+	}
+}
+
+// sameTemplate compares URL templates ignoring surrounding slashes and small typos.
+func sameTemplate(a, b string) bool {
+	return similarName(strings.Trim(a, "/"), strings.Trim(b, "/"))
+}
+
+// similarName reports whether two names differ only in case or by at most two edits.
+func similarName(a, b string) bool {
+	return editDistance([]rune(strings.ToLower(a)), []rune(strings.ToLower(b))) <= 2
+}
+
+// editDistance returns the Levenshtein distance between two strings.
+func editDistance(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// suggestion returns a "did you mean" hint for the first candidate similar to value.
+func suggestion(value string, candidates []string, similar func(a, b string) bool) string {
+	for _, candidate := range candidates {
+		if similar(value, candidate) {
+			return fmt.Sprintf("; did you mean %q?", candidate)
+		}
+	}
+	return ""
+}
+
+func serviceNames(services []reader.HTTPService) []string {
+	var names []string
+	for _, service := range services {
+		names = append(names, service.Properties.Name)
+	}
+	return names
+}
+
+// sortedNames lists the names a reference of the given section may resolve to.
+func sortedNames(global, local map[string]bool, section string) []string {
+	var names []string
+	for name := range global {
+		names = append(names, name)
+	}
+	if section == "schemas" {
+		for name := range local {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// jsonTree converts a value to its generic JSON representation.
+func jsonTree(value interface{}) map[string]interface{} {
+	var tree map[string]interface{}
+	data, err := json.Marshal(value)
+	if err == nil {
+		json.Unmarshal(data, &tree)
+	}
+	return tree
+}
+
+func asObject(value interface{}) map[string]interface{} {
+	object, _ := value.(map[string]interface{})
+	return object
+}
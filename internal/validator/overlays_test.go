@@ -0,0 +1,110 @@
+package validator
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"one_c_swagger/internal/reader"
+)
+
+func TestValidateOverlays(t *testing.T) {
+	var billing reader.HTTPService
+	billing.Properties.Name = "Биллинг"
+	for _, template := range []struct{ template, method string }{
+		{"/version", "GET"},
+		{"/bill/{Версия}/*", "POST"},
+	} {
+		var urlTemplate reader.URLTemplate
+		urlTemplate.Properties.Template = template.template
+		var method reader.Method
+		method.Properties.HTTPMethod = template.method
+		urlTemplate.Methods = append(urlTemplate.Methods, method)
+		billing.URLTemplates = append(billing.URLTemplates, urlTemplate)
+	}
+	services := []reader.HTTPService{billing}
+
+	allServices := `{
+		"defaultResponses": {"500": "#/components/responses/Error", "5xx": "Missing"},
+		"components": {
+			"responses": {"Error": {"description": "Error", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Error"}}}}},
+			"schemas": {"Error": {"type": "object"}, "Unused": {"type": "object"}}}}`
+
+	tests := []struct {
+		name     string
+		overlays map[string]string
+		want     []string
+	}{
+		{
+			name: "matching overlay",
+			overlays: map[string]string{"Биллинг": `{
+				"paths": {"/version": {"get": {"responses": {"200": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Version"}}}}}}}},
+				"components": {"schemas": {"Version": {"type": "object", "properties": {"error": {"$ref": "#/components/schemas/Error"}}}}}}`},
+		},
+		{
+			name:     "unknown service",
+			overlays: map[string]string{"Билинг": `{"paths": {}}`},
+			want:     []string{`error: Билинг.json#/: no HTTP service named "Билинг"; did you mean "Биллинг"?`},
+		},
+		{
+			name: "paths and methods",
+			overlays: map[string]string{"Биллинг": `{"paths": {
+				"version": {"get": {}},
+				"/bill/{Версия}/*": {"get": {}, "post": {}, "send": {}, "$ref": "#/paths/x", "x-internal": true}}}`},
+			want: []string{
+				`error: Биллинг.json#/paths/version: service Биллинг has no URL template "version"; did you mean "/version"?`,
+				`warning: Биллинг.json#/paths/~1bill~1{Версия}~1*/$ref: path item references are not supported and are ignored`,
+				`error: Биллинг.json#/paths/~1bill~1{Версия}~1*/$ref: reference "#/paths/x" does not point to a component`,
+				`error: Биллинг.json#/paths/~1bill~1{Версия}~1*/get: URL template "/bill/{Версия}/*" has no GET method; the operation is ignored`,
+				`error: Биллинг.json#/paths/~1bill~1{Версия}~1*/send: unknown HTTP method "send"`,
+			},
+		},
+		{
+			name: "references",
+			overlays: map[string]string{"Биллинг": `{
+				"paths": {"/version": {"get": {"responses": {
+					"200": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Versoin"}}}},
+					"404": {"$ref": "#/components/responses/NotFound"},
+					"500": {"$ref": "#/definitions/Error"}}}}},
+				"components": {"schemas": {"Version": {"type": "object"}}}}`},
+			want: []string{
+				`warning: Биллинг.json#/components/schemas/Version: schema "Version" is not referenced`,
+				`error: Биллинг.json#/paths/~1version/get/responses/200/content/application~1json/schema/$ref: reference "#/components/schemas/Versoin" does not resolve; did you mean "Version"?`,
+				`error: Биллинг.json#/paths/~1version/get/responses/404/$ref: reference "#/components/responses/NotFound" does not resolve`,
+				`error: Биллинг.json#/paths/~1version/get/responses/500/$ref: reference "#/definitions/Error" does not point to a component`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var allServicesConfig reader.AllServicesConfig
+			if err := json.Unmarshal([]byte(allServices), &allServicesConfig); err != nil {
+				t.Fatal(err)
+			}
+			overlays := make(map[string]*reader.SwaggerConfig)
+			for name, source := range tt.overlays {
+				var overlay reader.SwaggerConfig
+				if err := json.Unmarshal([]byte(source), &overlay); err != nil {
+					t.Fatal(err)
+				}
+				overlays[name] = &overlay
+			}
+
+			// The all services config is the same in every case.
+			want := []string{
+				`warning: all_services.json#/components/schemas/Unused: schema "Unused" is not referenced`,
+				`error: all_services.json#/defaultResponses/5xx: key "5xx" is not a status code or default`,
+				`error: all_services.json#/defaultResponses/5xx: response "Missing" is not declared in components.responses`,
+			}
+			want = append(want, tt.want...)
+
+			var got []string
+			for _, issue := range ValidateOverlays(services, overlays, &allServicesConfig, "all_services.json") {
+				got = append(got, issue.String())
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("ValidateOverlays() issues:\n%q\nwant\n%q", got, want)
+			}
+		})
+	}
+}
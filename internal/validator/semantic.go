@@ -0,0 +1,161 @@
+package validator
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// pathTemplateRe matches {name} segments of an OpenAPI path.
+var pathTemplateRe = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// checkReferences reports local references that do not resolve. External references are
+// not followed.
+func (v *validator) checkReferences(path string, value interface{}) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for _, key := range sortedKeys(value) {
+			if ref, ok := value[key].(string); ok && key == "$ref" {
+				if strings.HasPrefix(ref, "#") && v.resolve(ref) == nil {
+					v.errorf(pointer(path, key), "reference %q does not resolve", ref)
+				}
+				continue
+			}
+			v.checkReferences(pointer(path, key), value[key])
+		}
+	case []interface{}:
+		for i, item := range value {
+			v.checkReferences(pointer(path, strconv.Itoa(i)), item)
+		}
+	}
+}
+
+// resolve returns the value a local reference points to, or nil.
+func (v *validator) resolve(ref string) interface{} {
+	ref = strings.TrimPrefix(ref, "#")
+	var current interface{} = v.root
+	if ref == "" {
+		return current
+	}
+	if !strings.HasPrefix(ref, "/") {
+		return nil
+	}
+	for _, token := range strings.Split(ref[1:], "/") {
+		token = strings.ReplaceAll(token, "~1", "/")
+		token = strings.ReplaceAll(token, "~0", "~")
+		switch node := current.(type) {
+		case map[string]interface{}:
+			current = node[token]
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil
+			}
+			current = node[i]
+		default:
+			return nil
+		}
+		if current == nil {
+			return nil
+		}
+	}
+	return current
+}
+
+// checkPaths cross-checks operations: every template segment of a path has a path
+// parameter and vice versa, operation ids are unique and security requirements name
+// declared schemes.
+func (v *validator) checkPaths() {
+	schemes := make(map[string]bool)
+	if components, ok := v.root["components"].(map[string]interface{}); ok {
+		if securitySchemes, ok := components["securitySchemes"].(map[string]interface{}); ok {
+			for name := range securitySchemes {
+				schemes[name] = true
+			}
+		}
+	}
+	v.checkSecurityNames("/security", v.root["security"], schemes)
+
+	paths, _ := v.root["paths"].(map[string]interface{})
+	operationIDs := make(map[string]string)
+	for _, path := range sortedKeys(paths) {
+		item, ok := paths[path].(map[string]interface{})
+		if !ok || isExtension(path) {
+			continue
+		}
+		itemPath := pointer("/paths", path)
+
+		var templateNames []string
+		for _, match := range pathTemplateRe.FindAllStringSubmatch(path, -1) {
+			templateNames = append(templateNames, match[1])
+		}
+		itemParams := v.pathParameters(item["parameters"])
+
+		for _, method := range sortedKeys(item) {
+			op, ok := item[method].(map[string]interface{})
+			if !ok || !(contains(methods, method) || isOperation(op)) {
+				continue
+			}
+			opPath := pointer(itemPath, method)
+
+			declared := v.pathParameters(op["parameters"])
+			for name := range itemParams {
+				declared[name] = true
+			}
+			for _, name := range templateNames {
+				if !declared[name] {
+					v.errorf(opPath, "path parameter %q is not declared", name)
+				}
+			}
+			for name := range v.pathParameters(op["parameters"]) {
+				if !contains(templateNames, name) {
+					v.errorf(pointer(opPath, "parameters"), "path parameter %q does not occur in the path", name)
+				}
+			}
+
+			if id, ok := op["operationId"].(string); ok && id != "" {
+				if first, ok := operationIDs[id]; ok {
+					v.errorf(pointer(opPath, "operationId"), "operationId %q is already used by %s", id, first)
+				} else {
+					operationIDs[id] = opPath
+				}
+			}
+
+			v.checkSecurityNames(pointer(opPath, "security"), op["security"], schemes)
+		}
+	}
+}
+
+// pathParameters returns the names of the path parameters of a parameter list, following
+// references to components.
+func (v *validator) pathParameters(value interface{}) map[string]bool {
+	names := make(map[string]bool)
+	params, _ := value.([]interface{})
+	for _, paramValue := range params {
+		param, ok := paramValue.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if ref, ok := param["$ref"].(string); ok {
+			param, _ = v.resolve(ref).(map[string]interface{})
+		}
+		if param != nil && param["in"] == "path" {
+			if name, ok := param["name"].(string); ok {
+				names[name] = true
+			}
+		}
+	}
+	return names
+}
+
+func (v *validator) checkSecurityNames(path string, value interface{}, schemes map[string]bool) {
+	requirements, _ := value.([]interface{})
+	for i, requirementValue := range requirements {
+		requirement, _ := requirementValue.(map[string]interface{})
+		for _, name := range sortedKeys(requirement) {
+			if !schemes[name] {
+				v.errorf(pointer(path, strconv.Itoa(i), name), "security scheme %q is not declared in components", name)
+			}
+		}
+	}
+}
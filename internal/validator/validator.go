@@ -0,0 +1,653 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"one_c_swagger/internal/models"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Validation modes of the generated specification.
+const (
+	ModeOff  = "off"
+	ModeWarn = "warn"
+	ModeFail = "fail"
+)
+
+// Severity tells whether an issue makes the document invalid.
+type Severity string
+
+const (
+	// SeverityError marks a violation of the OpenAPI specification.
+	SeverityError Severity = "error"
+	// SeverityWarning marks a construct that is valid but likely to be ignored or rejected by tools.
+	SeverityWarning Severity = "warning"
+)
+
+// Issue is a problem found in a document. Path is a JSON pointer to the offending value;
+// File names the source file when the issue is not about the generated document.
+type Issue struct {
+	Severity Severity
+	File     string
+	Path     string
+	Message  string
+}
+
+func (i Issue) String() string {
+	if i.File != "" {
+		return fmt.Sprintf("%s: %s#%s: %s", i.Severity, i.File, i.Path, i.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", i.Severity, i.Path, i.Message)
+}
+
+// HasErrors reports whether any of the issues is an error.
+func HasErrors(issues []Issue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateOpenAPI runs the checks of Validate on a generated document.
+func ValidateOpenAPI(openapi *models.OpenAPI) ([]Issue, error) {
+	spec, err := json.Marshal(openapi)
+	if err != nil {
+		return nil, err
+	}
+	return Validate(spec)
+}
+
+// Validate runs structural checks on an OpenAPI 3.0 or 3.1 document in JSON form; it does
+// not validate the document against the official JSON schema. Issues are sorted by path.
+//
+// The structural checks are hand-written after the official OpenAPI JSON schema and cover
+// only part of it: required fields, unknown fields of the document, components, path items,
+// operations, parameters, headers, responses, media types and (3.0) schemas, the enumerated
+// values of parameter locations, schema and security scheme types, and the types of those
+// values. Contact, license, external docs, server variables, examples, encoding, links,
+// callbacks, oauth2 flows, parameter styles and the constraint keywords of schemas (minimum,
+// pattern, format and the like) are not checked; OpenAPI 3.1 schemas are only checked for
+// type, exclusiveMinimum, exclusiveMaximum, nullable and example.
+//
+// The semantic checks (semantic.go) cover what the schema cannot express: unresolved local
+// references, undeclared path parameters, duplicate operation ids and unknown security
+// schemes.
+func Validate(spec []byte) ([]Issue, error) {
+	var root map[string]interface{}
+	if err := json.Unmarshal(spec, &root); err != nil {
+		return nil, err
+	}
+
+	v := &validator{root: root}
+	if version, ok := root["openapi"].(string); ok {
+		v.openapi31 = strings.HasPrefix(version, "3.1.")
+	}
+	v.checkDocument()
+	v.checkReferences("", root)
+	v.checkPaths()
+	sort.Slice(v.issues, func(i, j int) bool {
+		if v.issues[i].Path != v.issues[j].Path {
+			return v.issues[i].Path < v.issues[j].Path
+		}
+		return v.issues[i].Message < v.issues[j].Message
+	})
+	return v.issues, nil
+}
+
+type validator struct {
+	root   map[string]interface{}
+	issues []Issue
+	// openapi31 switches to the rules of OpenAPI 3.1: webhooks, JSON Schema 2020-12 schemas.
+	openapi31 bool
+}
+
+func (v *validator) errorf(path, format string, args ...interface{}) {
+	v.issues = append(v.issues, Issue{Severity: SeverityError, Path: pathOrRoot(path), Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) warnf(path, format string, args ...interface{}) {
+	v.issues = append(v.issues, Issue{Severity: SeverityWarning, Path: pathOrRoot(path), Message: fmt.Sprintf(format, args...)})
+}
+
+var (
+	versionRe       = regexp.MustCompile(`^3\.[01]\.\d+(-.+)?$`)
+	responseCodeRe  = regexp.MustCompile(`^[1-5](\d\d|XX)$`)
+	componentNameRe = regexp.MustCompile(`^[a-zA-Z0-9.\-_]+$`)
+)
+
+var (
+	documentFields  = []string{"openapi", "info", "servers", "paths", "components", "security", "tags", "externalDocs"}
+	pathItemFields  = []string{"$ref", "summary", "description", "servers", "parameters"}
+	operationFields = []string{"tags", "summary", "description", "externalDocs", "operationId", "parameters", "requestBody", "responses", "callbacks", "deprecated", "security", "servers"}
+	parameterFields = []string{"name", "in", "description", "required", "deprecated", "allowEmptyValue", "style", "explode", "allowReserved", "schema", "example", "examples", "content"}
+	headerFields    = []string{"description", "required", "deprecated", "allowEmptyValue", "style", "explode", "allowReserved", "schema", "example", "examples", "content"}
+	responseFields  = []string{"description", "headers", "content", "links"}
+	mediaTypeFields = []string{"schema", "example", "examples", "encoding"}
+	schemaFields    = []string{"title", "multipleOf", "maximum", "exclusiveMaximum", "minimum", "exclusiveMinimum", "maxLength", "minLength", "pattern", "maxItems", "minItems", "uniqueItems", "maxProperties", "minProperties", "required", "enum", "type", "not", "allOf", "oneOf", "anyOf", "items", "properties", "additionalProperties", "description", "format", "default", "nullable", "discriminator", "readOnly", "writeOnly", "example", "externalDocs", "deprecated", "xml"}
+	componentFields = []string{"schemas", "responses", "parameters", "examples", "requestBodies", "headers", "securitySchemes", "links", "callbacks"}
+
+	// Fields added by OpenAPI 3.1. Schemas are JSON Schema 2020-12, which allows any keyword.
+	documentFields31  = []string{"jsonSchemaDialect", "webhooks"}
+	componentFields31 = []string{"pathItems"}
+
+	// methods are the operations of an OpenAPI 3.0 path item.
+	methods         = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+	parameterIn     = []string{"query", "header", "path", "cookie"}
+	schemaTypes     = []string{"array", "boolean", "integer", "number", "object", "string"}
+	schemaTypes31   = append([]string{"null"}, schemaTypes...)
+	securityTypes   = []string{"apiKey", "http", "oauth2", "openIdConnect"}
+	apiKeyLocations = []string{"query", "header", "cookie"}
+)
+
+func (v *validator) checkDocument() {
+	v.checkFields("", v.root, v.fields(documentFields, documentFields31))
+
+	if version, ok := v.root["openapi"].(string); !ok {
+		v.errorf("/openapi", "required string field is missing")
+	} else if !versionRe.MatchString(version) {
+		v.errorf("/openapi", "version %q is not an OpenAPI 3.0 or 3.1 version", version)
+	}
+
+	if info, ok := v.object("/info", v.root["info"], true); ok {
+		v.requireString("/info", info, "title")
+		v.requireString("/info", info, "version")
+	}
+
+	v.checkServers("/servers", v.root["servers"])
+
+	if v.openapi31 && v.root["paths"] == nil && v.root["components"] == nil && v.root["webhooks"] == nil {
+		v.errorf("", "one of paths, components or webhooks is required")
+	}
+	if paths, ok := v.object("/paths", v.root["paths"], !v.openapi31); ok {
+		for _, path := range sortedKeys(paths) {
+			if isExtension(path) {
+				continue
+			}
+			if !strings.HasPrefix(path, "/") {
+				v.errorf(pointer("/paths", path), "path must begin with /")
+			}
+			v.checkPathItem(pointer("/paths", path), paths[path])
+		}
+	}
+
+	if webhooks, ok := v.object("/webhooks", v.root["webhooks"], false); ok && v.openapi31 {
+		for _, name := range sortedKeys(webhooks) {
+			if !isExtension(name) {
+				v.checkPathItem(pointer("/webhooks", name), webhooks[name])
+			}
+		}
+	}
+
+	if components, ok := v.object("/components", v.root["components"], false); ok {
+		v.checkComponents(components)
+	}
+
+	v.checkSecurity("/security", v.root["security"])
+
+	if tags, ok := v.array("/tags", v.root["tags"]); ok {
+		seen := make(map[string]bool)
+		for i, tagValue := range tags {
+			path := pointer("/tags", strconv.Itoa(i))
+			tag, ok := v.object(path, tagValue, true)
+			if !ok {
+				continue
+			}
+			if name, ok := v.requireString(path, tag, "name"); ok {
+				if seen[name] {
+					v.errorf(path, "duplicate tag %q", name)
+				}
+				seen[name] = true
+			}
+		}
+	}
+}
+
+func (v *validator) checkServers(path string, value interface{}) {
+	servers, ok := v.array(path, value)
+	if !ok {
+		return
+	}
+	for i, serverValue := range servers {
+		serverPath := pointer(path, strconv.Itoa(i))
+		if server, ok := v.object(serverPath, serverValue, true); ok {
+			v.requireString(serverPath, server, "url")
+		}
+	}
+}
+
+func (v *validator) checkPathItem(path string, value interface{}) {
+	item, ok := v.object(path, value, true)
+	if !ok {
+		return
+	}
+	for _, key := range sortedKeys(item) {
+		fieldPath := pointer(path, key)
+		switch {
+		case isExtension(key) || contains(pathItemFields, key):
+		case contains(methods, key):
+			v.checkOperation(fieldPath, item[key])
+		case strings.ToLower(key) == key && isOperation(item[key]):
+			v.warnf(fieldPath, "%s is not an OpenAPI 3.0 method; tools will ignore the operation", strings.ToUpper(key))
+			v.checkOperation(fieldPath, item[key])
+		default:
+			v.errorf(fieldPath, "unknown field %q", key)
+		}
+	}
+	v.checkParameters(pointer(path, "parameters"), item["parameters"])
+	v.checkServers(pointer(path, "servers"), item["servers"])
+}
+
+func (v *validator) checkOperation(path string, value interface{}) {
+	op, ok := v.object(path, value, true)
+	if !ok {
+		return
+	}
+	v.checkFields(path, op, operationFields)
+
+	if tags, ok := v.array(pointer(path, "tags"), op["tags"]); ok {
+		for i, tag := range tags {
+			if _, ok := tag.(string); !ok {
+				v.errorf(pointer(path, "tags", strconv.Itoa(i)), "tag must be a string")
+			}
+		}
+	}
+	v.checkParameters(pointer(path, "parameters"), op["parameters"])
+
+	if body, ok := v.object(pointer(path, "requestBody"), op["requestBody"], false); ok && !isRef(body) {
+		v.checkContent(pointer(path, "requestBody", "content"), body["content"], true)
+	}
+
+	responsesPath := pointer(path, "responses")
+	if responses, ok := v.object(responsesPath, op["responses"], true); ok {
+		if len(responses) == 0 {
+			v.errorf(responsesPath, "at least one response is required")
+		}
+		for _, code := range sortedKeys(responses) {
+			if isExtension(code) {
+				continue
+			}
+			if code != "default" && !responseCodeRe.MatchString(code) {
+				v.errorf(pointer(responsesPath, code), "response key %q is not a status code or default", code)
+			}
+			v.checkResponse(pointer(responsesPath, code), responses[code])
+		}
+	}
+
+	v.checkSecurity(pointer(path, "security"), op["security"])
+	v.checkServers(pointer(path, "servers"), op["servers"])
+}
+
+func (v *validator) checkParameters(path string, value interface{}) {
+	params, ok := v.array(path, value)
+	if !ok {
+		return
+	}
+	for i, param := range params {
+		v.checkParameter(pointer(path, strconv.Itoa(i)), param)
+	}
+}
+
+func (v *validator) checkParameter(path string, value interface{}) {
+	param, ok := v.object(path, value, true)
+	if !ok || isRef(param) {
+		return
+	}
+	v.checkFields(path, param, parameterFields)
+	v.requireString(path, param, "name")
+	if in, ok := v.requireString(path, param, "in"); ok {
+		if !contains(parameterIn, in) {
+			v.errorf(pointer(path, "in"), "location %q must be one of %s", in, strings.Join(parameterIn, ", "))
+		} else if in == "path" && param["required"] != true {
+			v.errorf(pointer(path, "required"), "path parameters must be required")
+		}
+	}
+	v.checkSchemaOrContent(path, param)
+}
+
+func (v *validator) checkHeader(path string, value interface{}) {
+	header, ok := v.object(path, value, true)
+	if !ok || isRef(header) {
+		return
+	}
+	v.checkFields(path, header, headerFields)
+	v.checkSchemaOrContent(path, header)
+}
+
+// checkSchemaOrContent checks that a parameter or header is described either by a schema or
+// by a content map with a single entry.
+func (v *validator) checkSchemaOrContent(path string, object map[string]interface{}) {
+	_, hasSchema := object["schema"]
+	_, hasContent := object["content"]
+	switch {
+	case hasSchema && hasContent:
+		v.errorf(path, "schema and content are mutually exclusive")
+	case !hasSchema && !hasContent:
+		v.errorf(path, "either schema or content is required")
+	case hasSchema:
+		v.checkSchema(pointer(path, "schema"), object["schema"])
+	default:
+		if content, ok := v.object(pointer(path, "content"), object["content"], true); ok && len(content) != 1 {
+			v.errorf(pointer(path, "content"), "content must contain exactly one media type")
+		}
+		v.checkContent(pointer(path, "content"), object["content"], false)
+	}
+}
+
+func (v *validator) checkResponse(path string, value interface{}) {
+	response, ok := v.object(path, value, true)
+	if !ok || isRef(response) {
+		return
+	}
+	v.checkFields(path, response, responseFields)
+	v.requireString(path, response, "description")
+	if headers, ok := v.object(pointer(path, "headers"), response["headers"], false); ok {
+		for _, name := range sortedKeys(headers) {
+			v.checkHeader(pointer(path, "headers", name), headers[name])
+		}
+	}
+	v.checkContent(pointer(path, "content"), response["content"], false)
+}
+
+func (v *validator) checkContent(path string, value interface{}, required bool) {
+	content, ok := v.object(path, value, required)
+	if !ok {
+		return
+	}
+	for _, mediaType := range sortedKeys(content) {
+		mediaPath := pointer(path, mediaType)
+		media, ok := v.object(mediaPath, content[mediaType], true)
+		if !ok {
+			continue
+		}
+		v.checkFields(mediaPath, media, mediaTypeFields)
+		if schema, ok := media["schema"]; ok {
+			v.checkSchema(pointer(mediaPath, "schema"), schema)
+		}
+	}
+}
+
+func (v *validator) checkSchema(path string, value interface{}) {
+	schema, ok := v.object(path, value, true)
+	if !ok || isRef(schema) {
+		return
+	}
+	if v.openapi31 {
+		v.checkSchemaType31(path, schema)
+	} else {
+		v.checkFields(path, schema, schemaFields)
+		v.checkSchemaType(path, schema)
+	}
+
+	if required, ok := v.array(pointer(path, "required"), schema["required"]); ok {
+		if len(required) == 0 {
+			v.errorf(pointer(path, "required"), "required must not be empty")
+		}
+		for i, name := range required {
+			if _, ok := name.(string); !ok {
+				v.errorf(pointer(path, "required", strconv.Itoa(i)), "property name must be a string")
+			}
+		}
+	}
+	if properties, ok := v.object(pointer(path, "properties"), schema["properties"], false); ok {
+		for _, name := range sortedKeys(properties) {
+			v.checkSchema(pointer(path, "properties", name), properties[name])
+		}
+	}
+	for _, keyword := range []string{"items", "not"} {
+		if sub, ok := schema[keyword]; ok {
+			v.checkSchema(pointer(path, keyword), sub)
+		}
+	}
+	if additional, ok := schema["additionalProperties"]; ok {
+		if _, isBool := additional.(bool); !isBool {
+			v.checkSchema(pointer(path, "additionalProperties"), additional)
+		}
+	}
+	for _, keyword := range []string{"allOf", "oneOf", "anyOf"} {
+		if subs, ok := v.array(pointer(path, keyword), schema[keyword]); ok {
+			for i, sub := range subs {
+				v.checkSchema(pointer(path, keyword, strconv.Itoa(i)), sub)
+			}
+		}
+	}
+}
+
+func (v *validator) checkSchemaType(path string, schema map[string]interface{}) {
+	if typeValue, ok := schema["type"]; ok {
+		typ, isString := typeValue.(string)
+		switch {
+		case !isString:
+			v.errorf(pointer(path, "type"), "type must be a string")
+		case !contains(schemaTypes, typ):
+			v.errorf(pointer(path, "type"), "type %q must be one of %s", typ, strings.Join(schemaTypes, ", "))
+		case typ == "array" && schema["items"] == nil:
+			v.errorf(path, "items is required for arrays")
+		}
+	}
+	for _, keyword := range []string{"exclusiveMaximum", "exclusiveMinimum"} {
+		if value, ok := schema[keyword]; ok {
+			if _, isBool := value.(bool); !isBool {
+				v.errorf(pointer(path, keyword), "%s must be a boolean", keyword)
+			}
+		}
+	}
+}
+
+// checkSchemaType31 checks the keywords whose meaning changed from OpenAPI 3.0 to 3.1.
+func (v *validator) checkSchemaType31(path string, schema map[string]interface{}) {
+	if typeValue, ok := schema["type"]; ok {
+		types, isList := typeValue.([]interface{})
+		if !isList {
+			types = []interface{}{typeValue}
+		}
+		for _, item := range types {
+			typ, isString := item.(string)
+			switch {
+			case !isString:
+				v.errorf(pointer(path, "type"), "type must be a string or an array of strings")
+			case !contains(schemaTypes31, typ):
+				v.errorf(pointer(path, "type"), "type %q must be one of %s", typ, strings.Join(schemaTypes31, ", "))
+			}
+		}
+	}
+	for _, keyword := range []string{"exclusiveMaximum", "exclusiveMinimum"} {
+		if value, ok := schema[keyword]; ok {
+			if _, isNumber := value.(float64); !isNumber {
+				v.errorf(pointer(path, keyword), "%s must be a number in OpenAPI 3.1", keyword)
+			}
+		}
+	}
+	if _, ok := schema["nullable"]; ok {
+		v.warnf(pointer(path, "nullable"), "nullable is not part of OpenAPI 3.1; add \"null\" to type")
+	}
+	if _, ok := schema["example"]; ok {
+		v.warnf(pointer(path, "example"), "example is deprecated in OpenAPI 3.1; use examples")
+	}
+}
+
+// fields returns the allowed fields of an object for the version of the document.
+func (v *validator) fields(fields, added31 []string) []string {
+	if !v.openapi31 {
+		return fields
+	}
+	return append(append([]string(nil), fields...), added31...)
+}
+
+func (v *validator) checkComponents(components map[string]interface{}) {
+	v.checkFields("/components", components, v.fields(componentFields, componentFields31))
+	for _, section := range sortedKeys(components) {
+		if isExtension(section) {
+			continue
+		}
+		sectionPath := pointer("/components", section)
+		entries, ok := v.object(sectionPath, components[section], true)
+		if !ok {
+			continue
+		}
+		for _, name := range sortedKeys(entries) {
+			path := pointer(sectionPath, name)
+			if !componentNameRe.MatchString(name) {
+				v.warnf(path, "component name %q should match %s; consider the transliteration option", name, componentNameRe)
+			}
+			switch section {
+			case "schemas":
+				v.checkSchema(path, entries[name])
+			case "responses":
+				v.checkResponse(path, entries[name])
+			case "parameters":
+				v.checkParameter(path, entries[name])
+			case "headers":
+				v.checkHeader(path, entries[name])
+			case "requestBodies":
+				if body, ok := v.object(path, entries[name], true); ok && !isRef(body) {
+					v.checkContent(pointer(path, "content"), body["content"], true)
+				}
+			case "securitySchemes":
+				v.checkSecurityScheme(path, entries[name])
+			}
+		}
+	}
+}
+
+func (v *validator) checkSecurityScheme(path string, value interface{}) {
+	scheme, ok := v.object(path, value, true)
+	if !ok || isRef(scheme) {
+		return
+	}
+	typ, ok := v.requireString(path, scheme, "type")
+	if !ok {
+		return
+	}
+	switch typ {
+	case "apiKey":
+		v.requireString(path, scheme, "name")
+		if in, ok := v.requireString(path, scheme, "in"); ok && !contains(apiKeyLocations, in) {
+			v.errorf(pointer(path, "in"), "location %q must be one of %s", in, strings.Join(apiKeyLocations, ", "))
+		}
+	case "http":
+		v.requireString(path, scheme, "scheme")
+	case "oauth2":
+		v.object(pointer(path, "flows"), scheme["flows"], true)
+	case "openIdConnect":
+		v.requireString(path, scheme, "openIdConnectUrl")
+	default:
+		v.errorf(pointer(path, "type"), "type %q must be one of %s", typ, strings.Join(securityTypes, ", "))
+	}
+}
+
+func (v *validator) checkSecurity(path string, value interface{}) {
+	requirements, ok := v.array(path, value)
+	if !ok {
+		return
+	}
+	for i, requirement := range requirements {
+		v.object(pointer(path, strconv.Itoa(i)), requirement, true)
+	}
+}
+
+// checkFields reports fields that the object does not define. Extensions are always allowed.
+func (v *validator) checkFields(path string, object map[string]interface{}, fields []string) {
+	for _, key := range sortedKeys(object) {
+		if !isExtension(key) && !contains(fields, key) {
+			v.errorf(pointer(path, key), "unknown field %q", key)
+		}
+	}
+}
+
+// object returns the value as a JSON object, reporting a wrong type and, if required, absence.
+func (v *validator) object(path string, value interface{}, required bool) (map[string]interface{}, bool) {
+	if value == nil {
+		if required {
+			v.errorf(path, "required object is missing")
+		}
+		return nil, false
+	}
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		v.errorf(path, "must be an object")
+	}
+	return object, ok
+}
+
+// array returns the value as a JSON array; absent arrays are optional.
+func (v *validator) array(path string, value interface{}) ([]interface{}, bool) {
+	if value == nil {
+		return nil, false
+	}
+	array, ok := value.([]interface{})
+	if !ok {
+		v.errorf(path, "must be an array")
+	}
+	return array, ok
+}
+
+func (v *validator) requireString(path string, object map[string]interface{}, field string) (string, bool) {
+	value, ok := object[field].(string)
+	if !ok {
+		if _, present := object[field]; present {
+			v.errorf(pointer(path, field), "must be a string")
+		} else {
+			v.errorf(pointer(path, field), "required string field is missing")
+		}
+	}
+	return value, ok
+}
+
+func isExtension(key string) bool {
+	return strings.HasPrefix(key, "x-")
+}
+
+func isRef(object map[string]interface{}) bool {
+	_, ok := object["$ref"]
+	return ok
+}
+
+// isOperation tells a non-standard method from other unknown fields of a path item.
+func isOperation(value interface{}) bool {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	_, hasResponses := object["responses"]
+	return hasResponses
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// pointer appends reference tokens to a JSON pointer, escaping them as RFC 6901 requires.
+func pointer(base string, tokens ...string) string {
+	for _, token := range tokens {
+		token = strings.ReplaceAll(token, "~", "~0")
+		token = strings.ReplaceAll(token, "/", "~1")
+		base += "/" + token
+	}
+	return base
+}
+
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
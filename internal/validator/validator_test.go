@@ -0,0 +1,129 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+		want []string
+	}{
+		{
+			name: "valid document",
+			spec: `{"openapi": "3.0.3", "info": {"title": "API", "version": "1.0"},
+				"paths": {"/orders/{id}": {"get": {"operationId": "getOrder",
+					"parameters": [{"$ref": "#/components/parameters/id"}],
+					"responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Order"}}}}},
+					"security": [{"basic": []}]}}},
+				"components": {
+					"schemas": {"Order": {"type": "object", "required": ["id"], "properties": {"id": {"type": "string"}}, "x-1c-name": "Заказ"}},
+					"parameters": {"id": {"name": "id", "in": "path", "required": true, "schema": {"type": "string"}}},
+					"securitySchemes": {"basic": {"type": "http", "scheme": "basic"}}}}`,
+		},
+		{
+			name: "missing required fields",
+			spec: `{"openapi": "2.0", "info": {"title": "API"},
+				"paths": {"/orders": {"get": {"responses": {"200": {}}}}}}`,
+			want: []string{
+				`error: /info/version: required string field is missing`,
+				`error: /openapi: version "2.0" is not an OpenAPI 3.0 or 3.1 version`,
+				`error: /paths/~1orders/get/responses/200/description: required string field is missing`,
+			},
+		},
+		{
+			name: "unknown fields and values",
+			spec: `{"openapi": "3.0.3", "info": {"title": "API", "version": "1.0"}, "webhooks": {},
+				"paths": {"orders": {"get": {"summery": "typo",
+					"parameters": [{"name": "q", "in": "body", "schema": {"type": "text"}}],
+					"responses": {"OK": {"description": "OK"}}}}}}`,
+			want: []string{
+				`error: /paths/orders: path must begin with /`,
+				`error: /paths/orders/get/parameters/0/in: location "body" must be one of query, header, path, cookie`,
+				`error: /paths/orders/get/parameters/0/schema/type: type "text" must be one of array, boolean, integer, number, object, string`,
+				`error: /paths/orders/get/responses/OK: response key "OK" is not a status code or default`,
+				`error: /paths/orders/get/summery: unknown field "summery"`,
+				`error: /webhooks: unknown field "webhooks"`,
+			},
+		},
+		{
+			name: "parameters",
+			spec: `{"openapi": "3.0.3", "info": {"title": "API", "version": "1.0"},
+				"paths": {"/orders/{id}": {"get": {
+					"parameters": [
+						{"name": "page", "in": "path", "schema": {"type": "array"}},
+						{"name": "filter", "in": "query", "schema": {}, "content": {}}],
+					"responses": {"200": {"description": "OK"}}}}}}`,
+			want: []string{
+				`error: /paths/~1orders~1{id}/get: path parameter "id" is not declared`,
+				`error: /paths/~1orders~1{id}/get/parameters: path parameter "page" does not occur in the path`,
+				`error: /paths/~1orders~1{id}/get/parameters/0/required: path parameters must be required`,
+				`error: /paths/~1orders~1{id}/get/parameters/0/schema: items is required for arrays`,
+				`error: /paths/~1orders~1{id}/get/parameters/1: schema and content are mutually exclusive`,
+			},
+		},
+		{
+			name: "references, operation ids and security",
+			spec: `{"openapi": "3.0.3", "info": {"title": "API", "version": "1.0"},
+				"paths": {
+					"/a": {"get": {"operationId": "get", "responses": {"200": {"$ref": "#/components/responses/Missing"}}}},
+					"/b": {"get": {"operationId": "get", "responses": {"200": {"description": "OK"}}, "security": [{"token": []}]}}}}`,
+			want: []string{
+				`error: /paths/~1a/get/responses/200/$ref: reference "#/components/responses/Missing" does not resolve`,
+				`error: /paths/~1b/get/operationId: operationId "get" is already used by /paths/~1a/get`,
+				`error: /paths/~1b/get/security/0/token: security scheme "token" is not declared in components`,
+			},
+		},
+		{
+			name: "warnings",
+			spec: `{"openapi": "3.0.3", "info": {"title": "API", "version": "1.0"},
+				"paths": {"/files": {"propfind": {"responses": {"207": {"description": "Multi-Status"}}}}},
+				"components": {"schemas": {"Заказ": {"type": "object"}}}}`,
+			want: []string{
+				`warning: /components/schemas/Заказ: component name "Заказ" should match ^[a-zA-Z0-9.\-_]+$; consider the transliteration option`,
+				`warning: /paths/~1files/propfind: PROPFIND is not an OpenAPI 3.0 method; tools will ignore the operation`,
+			},
+		},
+		{
+			name: "OpenAPI 3.1 schemas",
+			spec: `{"openapi": "3.1.0", "info": {"title": "API", "version": "1.0"},
+				"webhooks": {"created": {"post": {"responses": {"200": {"description": "OK"}}}}},
+				"components": {"schemas": {
+					"Order": {"type": ["object", "null"], "$defs": {}, "exclusiveMinimum": true, "nullable": true},
+					"Line": {"type": "integer", "exclusiveMaximum": 10, "example": 1}}}}`,
+			want: []string{
+				`warning: /components/schemas/Line/example: example is deprecated in OpenAPI 3.1; use examples`,
+				`error: /components/schemas/Order/exclusiveMinimum: exclusiveMinimum must be a number in OpenAPI 3.1`,
+				`warning: /components/schemas/Order/nullable: nullable is not part of OpenAPI 3.1; add "null" to type`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := Validate([]byte(tt.spec))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, issue := range issues {
+				got = append(got, issue.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() issues:\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasErrors(t *testing.T) {
+	warning := Issue{Severity: SeverityWarning, Path: "/", Message: "warning"}
+	failure := Issue{Severity: SeverityError, Path: "/", Message: "error"}
+	if HasErrors([]Issue{warning}) {
+		t.Error("HasErrors() = true for warnings only")
+	}
+	if !HasErrors([]Issue{warning, failure}) {
+		t.Error("HasErrors() = false with an error")
+	}
+}